@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+	"github.com/urfave/cli"
+)
+
+// contributorFlags are the flags of contributors invite and contributors
+// update.
+var contributorFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "admin",
+		Usage: "Grant admin rights, which include access to all languages. (`0/1`)",
+	},
+	cli.StringFlag{
+		Name:  "reviewer",
+		Usage: "Allow reviewing translations. (`0/1`)",
+	},
+	cli.StringFlag{
+		Name:  "langs",
+		Usage: "Languages the contributor may translate. Replaces previous permissions. (comma separated)",
+	},
+	cli.StringFlag{
+		Name:  "read_langs",
+		Usage: "Languages the contributor may only read, e.g. the base language. (comma separated)",
+	},
+}
+
+var contributorsCommand = cli.Command{
+	Name:  "contributors",
+	Usage: "Manage who can work on a project.",
+	Subcommands: []cli.Command{
+		{
+			Name:      "list",
+			Usage:     "List project contributors and their languages.",
+			ArgsUsage: "<project id>",
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				contributors, err := client.ListContributors(ctx, projectID)
+				if err != nil {
+					return apiError(err)
+				}
+				cWhite := color.New(color.FgHiWhite)
+				cGreen := color.New(color.FgGreen)
+				cRed := color.New(color.FgRed)
+				cCyan := color.New(color.FgCyan)
+				for _, contributor := range contributors {
+					cWhite.Printf("%-10d ", contributor.ID)
+					if contributor.Role().IsAdmin() {
+						cGreen.Print("(admin) ")
+					} else {
+						cRed.Print("(contr) ")
+					}
+					fmt.Print(contributor.Email)
+					var languages []string
+					for _, l := range contributor.Languages {
+						if !l.Writable {
+							languages = append(languages, l.ISO+" (read)")
+							continue
+						}
+						languages = append(languages, l.ISO)
+					}
+					if len(languages) != 0 {
+						cCyan.Print(" " + strings.Join(languages, ", "))
+					}
+					fmt.Println()
+				}
+				return nil
+			},
+		},
+		{
+			Name:      "invite",
+			Usage:     "Invite a contributor to the project.",
+			ArgsUsage: "<project id>",
+			Flags: append([]cli.Flag{
+				cli.StringFlag{
+					Name:  "email",
+					Usage: "Email address of the contributor. (required)",
+				},
+				cli.StringFlag{
+					Name:  "fullname",
+					Usage: "Name of the contributor.",
+				},
+			}, contributorFlags...),
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				email := c.String("email")
+				if email == "" {
+					return cli.NewExitError("ERROR: --email is required. Run `lokalise help contributors invite` for all options.", 5)
+				}
+				opts := contributorOptions(c)
+				if v := c.String("fullname"); v != "" {
+					opts = append(opts, lokalise.WithFullname(v))
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				contributor, err := client.InviteContributor(ctx, projectID, email, opts...)
+				if err != nil {
+					return apiError(err)
+				}
+				color.New(color.FgGreen).Print("Invited ")
+				fmt.Println(contributor.ID, contributor.Email)
+				return nil
+			},
+		},
+		{
+			Name:      "update",
+			Usage:     "Change language permissions and admin rights of a contributor.",
+			ArgsUsage: "<project id>",
+			Flags: append([]cli.Flag{
+				cli.Int64Flag{
+					Name:  "user_id",
+					Usage: "ID of the contributor. (required)",
+				},
+			}, contributorFlags...),
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				userID := c.Int64("user_id")
+				if userID == 0 {
+					return cli.NewExitError("ERROR: --user_id is required. Run `lokalise help contributors update` for all options.", 5)
+				}
+				opts := contributorOptions(c)
+				if len(opts) == 0 {
+					return cli.NewExitError("ERROR: nothing to change. Run `lokalise help contributors update` for all options.", 5)
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				contributor, err := client.UpdateContributor(ctx, projectID, userID, opts...)
+				if err != nil {
+					return apiError(err)
+				}
+				color.New(color.FgGreen).Print("Updated ")
+				fmt.Println(contributor.ID, contributor.Email)
+				return nil
+			},
+		},
+		{
+			Name:      "remove",
+			Usage:     "Remove a contributor from the project.",
+			ArgsUsage: "<project id>",
+			Flags: []cli.Flag{
+				cli.Int64Flag{
+					Name:  "user_id",
+					Usage: "ID of the contributor. (required)",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				userID := c.Int64("user_id")
+				if userID == 0 {
+					return cli.NewExitError("ERROR: --user_id is required. Run `lokalise help contributors remove` for all options.", 5)
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				if err := client.RemoveContributor(ctx, projectID, userID); err != nil {
+					return apiError(err)
+				}
+				color.New(color.FgGreen).Print("Removed ")
+				fmt.Println(userID)
+				return nil
+			},
+		},
+	},
+}
+
+// contributorOptions returns the ContributorOptions set by contributorFlags.
+func contributorOptions(c *cli.Context) []lokalise.ContributorOption {
+	var opts []lokalise.ContributorOption
+	if v := c.String("admin"); v != "" {
+		b, _ := strconv.ParseBool(v)
+		opts = append(opts, lokalise.WithAdmin(b))
+	}
+	if v := c.String("reviewer"); v != "" {
+		b, _ := strconv.ParseBool(v)
+		opts = append(opts, lokalise.WithReviewer(b))
+	}
+	var permissions []lokalise.LanguagePermission
+	for _, iso := range commaSlice(c.String("langs")) {
+		permissions = append(permissions, lokalise.LanguagePermission{ISO: iso, Writable: true})
+	}
+	for _, iso := range commaSlice(c.String("read_langs")) {
+		permissions = append(permissions, lokalise.LanguagePermission{ISO: iso})
+	}
+	if len(permissions) != 0 {
+		opts = append(opts, lokalise.WithLanguagePermissions(permissions...))
+	}
+	return opts
+}
@@ -1,4 +1,4 @@
-module lokalise/lokalise-cli-go
+module github.com/lokalise/lokalise-cli-go
 
 go 1.13
 
@@ -6,6 +6,5 @@ require (
 	github.com/BurntSushi/toml v0.3.1
 	github.com/briandowns/spinner v1.9.0
 	github.com/fatih/color v1.9.0
-	github.com/lokalise/lokalise-cli-go v0.0.0-20190914091515-7223513a2eb4
 	github.com/urfave/cli v1.22.2
 )
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+	"github.com/urfave/cli"
+)
+
+// keyFlags are the flags of keys create and keys update.
+var keyFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "file",
+		Usage: "JSON `file` with an array of keys, for changing many keys at once. See https://lokalise.co/apidocs#keys",
+	},
+	cli.StringFlag{
+		Name:  "key_name",
+		Usage: "Key name.",
+	},
+	cli.StringFlag{
+		Name:  "platforms",
+		Usage: "Key platforms, comma separated. " + allowed("platforms"),
+	},
+	cli.StringFlag{
+		Name:  "tags",
+		Usage: "Key tags. (comma separated)",
+	},
+	cli.StringFlag{
+		Name:  "description",
+		Usage: "Key description.",
+	},
+	cli.StringFlag{
+		Name:  "context",
+		Usage: "Key context.",
+	},
+	cli.StringFlag{
+		Name:  "filename",
+		Usage: "File the key is exported to with --use_original=1.",
+	},
+	cli.StringSliceFlag{
+		Name:  "translation",
+		Usage: "Translation as `iso=text`. Repeat for several languages.",
+	},
+}
+
+var keysCommand = cli.Command{
+	Name:  "keys",
+	Usage: "Manage project keys.",
+	Subcommands: []cli.Command{
+		{
+			Name:      "list",
+			Usage:     "List project keys.",
+			ArgsUsage: "<project id>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "tags",
+					Usage: "Only list keys with any of these tags. (comma separated)",
+				},
+				cli.StringFlag{
+					Name:  "filenames",
+					Usage: "Only list keys assigned to any of these files. (comma separated)",
+				},
+				cli.StringFlag{
+					Name:  "platforms",
+					Usage: "Only list keys assigned to any of these platforms, comma separated. " + allowed("platforms"),
+				},
+				cli.IntFlag{
+					Name:  "page",
+					Usage: "List a single page, starting at 1. Lists all keys by default.",
+				},
+				cli.IntFlag{
+					Name:  "limit",
+					Usage: "Keys per page.",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				var opts []lokalise.ListOption
+				if tags := commaSlice(c.String("tags")); len(tags) != 0 {
+					opts = append(opts, lokalise.WithKeyTags(tags...))
+				}
+				if filenames := commaSlice(c.String("filenames")); len(filenames) != 0 {
+					opts = append(opts, lokalise.WithKeyFilenames(filenames...))
+				}
+				if platforms := platformSlice(c.String("platforms")); len(platforms) != 0 {
+					opts = append(opts, lokalise.WithKeyPlatforms(platforms...))
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				cWhite := color.New(color.FgHiWhite)
+				cCyan := color.New(color.FgCyan)
+				page := c.Int("page")
+				for p := 1; ; p++ {
+					if page > 0 {
+						p = page
+					}
+					keys, err := client.ListKeys(ctx, projectID, append(opts, lokalise.WithPage(p, c.Int("limit")))...)
+					if err != nil {
+						return apiError(err)
+					}
+					for _, k := range keys.Keys {
+						cWhite.Printf("%-10d ", k.ID)
+						fmt.Print(k.Name)
+						if len(k.Tags) != 0 {
+							cCyan.Printf(" [%s]", strings.Join(k.Tags, ", "))
+						}
+						fmt.Println()
+					}
+					if page > 0 || !keys.Next() {
+						return nil
+					}
+				}
+			},
+		},
+		{
+			Name:      "create",
+			Usage:     "Create keys from flags or a JSON file.",
+			ArgsUsage: "<project id>",
+			Flags:     keyFlags,
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				keys, err := keysFromFlags(c)
+				if err != nil {
+					return err
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				keys, err = client.CreateKeys(ctx, projectID, keys)
+				if err != nil {
+					return apiError(err)
+				}
+				printKeys("Created", keys)
+				return nil
+			},
+		},
+		{
+			Name:      "update",
+			Usage:     "Update keys from flags or a JSON file. Keys are identified by their ID.",
+			ArgsUsage: "<project id>",
+			Flags: append([]cli.Flag{
+				cli.Int64Flag{
+					Name:  "key_id",
+					Usage: "ID of the key to update, unless --file is used.",
+				},
+			}, keyFlags...),
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				keys, err := keysFromFlags(c)
+				if err != nil {
+					return err
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				keys, err = client.UpdateKeys(ctx, projectID, keys)
+				if err != nil {
+					return apiError(err)
+				}
+				printKeys("Updated", keys)
+				return nil
+			},
+		},
+		{
+			Name:      "delete",
+			Usage:     "Delete keys.",
+			ArgsUsage: "<project id>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "key_ids",
+					Usage: "IDs of the keys to delete. (comma separated, required)",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				ids, err := keyIDSlice(c.String("key_ids"))
+				if err != nil {
+					return err
+				}
+				if len(ids) == 0 {
+					return cli.NewExitError("ERROR: --key_ids is required. Run `lokalise help keys delete` for all options.", 5)
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				if err := client.DeleteKeys(ctx, projectID, ids...); err != nil {
+					return apiError(err)
+				}
+				color.New(color.FgGreen).Print("Deleted ")
+				fmt.Println(len(ids), "keys.")
+				return nil
+			},
+		},
+	},
+}
+
+// keysFromFlags returns the keys of the --file flag, or the single key
+// described by the other flags.
+func keysFromFlags(c *cli.Context) ([]lokalise.Key, error) {
+	if file := c.String("file"); file != "" {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, cli.NewExitError("ERROR: "+err.Error(), 5)
+		}
+		var keys []lokalise.Key
+		if err := json.Unmarshal(content, &keys); err != nil {
+			return nil, cli.NewExitError("ERROR: reading "+file+": "+err.Error(), 5)
+		}
+		return keys, nil
+	}
+
+	k := lokalise.Key{
+		ID:          c.Int64("key_id"),
+		Name:        c.String("key_name"),
+		Description: c.String("description"),
+		Context:     c.String("context"),
+		Platforms:   platformSlice(c.String("platforms")),
+		Tags:        commaSlice(c.String("tags")),
+		Filename:    c.String("filename"),
+	}
+	for _, t := range c.StringSlice("translation") {
+		i := strings.Index(t, "=")
+		if i < 0 {
+			return nil, cli.NewExitError("ERROR: --translation must be iso=text, got "+t+".", 5)
+		}
+		if k.Translations == nil {
+			k.Translations = map[string]string{}
+		}
+		k.Translations[t[:i]] = t[i+1:]
+	}
+	return []lokalise.Key{k}, nil
+}
+
+func printKeys(action string, keys []lokalise.Key) {
+	cGreen := color.New(color.FgGreen)
+	for _, k := range keys {
+		cGreen.Print(action + " ")
+		fmt.Println(k.ID, k.Name)
+	}
+}
+
+func platformSlice(v string) []lokalise.Platform {
+	var platforms []lokalise.Platform
+	for _, s := range commaSlice(v) {
+		platforms = append(platforms, lokalise.Platform(s))
+	}
+	return platforms
+}
+
+// keyIDSlice parses the comma separated key IDs of v.
+func keyIDSlice(v string) ([]int64, error) {
+	var ids []int64
+	for _, s := range commaSlice(v) {
+		id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return nil, cli.NewExitError("ERROR: invalid key ID "+s+".", 5)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// allKeys returns the keys of project with ID projectID matching opts from
+// all pages.
+func allKeys(ctx context.Context, client *lokalise.Client, projectID string, opts ...lokalise.ListOption) ([]lokalise.Key, error) {
+	var keys []lokalise.Key
+	for p := 1; ; p++ {
+		page, err := client.ListKeys(ctx, projectID, append(opts, lokalise.WithPage(p, 0))...)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, page.Keys...)
+		if !page.Next() {
+			return keys, nil
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+	"github.com/urfave/cli"
+)
+
+var languagesCommand = cli.Command{
+	Name:  "languages",
+	Usage: "Manage project languages.",
+	Subcommands: []cli.Command{
+		{
+			Name:      "list",
+			Usage:     "List project languages with their translation progress.",
+			ArgsUsage: "<project id>",
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				languages, err := client.ListLanguages(ctx, projectID)
+				if err != nil {
+					return apiError(err)
+				}
+				cWhite := color.New(color.FgHiWhite)
+				cCyan := color.New(color.FgCyan)
+				for _, lang := range languages {
+					cWhite.Printf("%-10s", lang.ISO)
+					cCyan.Printf(" %3d%% ", lang.Progress)
+					fmt.Println(lang.Name)
+				}
+				return nil
+			},
+		},
+		{
+			Name:      "add",
+			Usage:     "Add a language to the project.",
+			ArgsUsage: "<project id>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "lang_iso",
+					Usage: "Language code to add. (required)",
+				},
+				cli.StringFlag{
+					Name:  "custom_iso",
+					Usage: "Custom language code, e.g. for a market specific variant.",
+				},
+				cli.StringFlag{
+					Name:  "custom_name",
+					Usage: "Custom language name.",
+				},
+				cli.StringFlag{
+					Name:  "custom_plural_forms",
+					Usage: "Custom plural forms. (zero, one, two, few, many, other; comma separated)",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				iso := c.String("lang_iso")
+				if iso == "" {
+					return cli.NewExitError("ERROR: --lang_iso is required. Run `lokalise help languages add` for all options.", 5)
+				}
+				var opts []lokalise.LanguageOption
+				if v := c.String("custom_iso"); v != "" {
+					opts = append(opts, lokalise.WithCustomISO(v))
+				}
+				if v := c.String("custom_name"); v != "" {
+					opts = append(opts, lokalise.WithCustomName(v))
+				}
+				if v := commaSlice(c.String("custom_plural_forms")); len(v) != 0 {
+					opts = append(opts, lokalise.WithCustomPluralForms(v...))
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				lang, err := client.AddLanguage(ctx, projectID, iso, opts...)
+				if err != nil {
+					return apiError(err)
+				}
+				color.New(color.FgGreen).Print("Added ")
+				fmt.Println(lang.ISO, lang.Name)
+				return nil
+			},
+		},
+		{
+			Name:      "remove",
+			Usage:     "Remove a language and all its translations from the project.",
+			ArgsUsage: "<project id>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "lang_iso",
+					Usage: "Language code to remove. (required)",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				iso := c.String("lang_iso")
+				if iso == "" {
+					return cli.NewExitError("ERROR: --lang_iso is required. Run `lokalise help languages remove` for all options.", 5)
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				if err := client.RemoveLanguage(ctx, projectID, iso); err != nil {
+					return apiError(err)
+				}
+				color.New(color.FgGreen).Print("Removed ")
+				fmt.Println(iso)
+				return nil
+			},
+		},
+	},
+}
@@ -1,11 +1,11 @@
 package main
 
 import (
-	"archive/zip"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
+	"io/ioutil"
 	"os"
+	"os/signal"
 	"path"
 	"path/filepath"
 	"strconv"
@@ -19,15 +19,18 @@ import (
 	"github.com/urfave/cli"
 )
 
+// Config is the configuration file format.
+type Config struct {
+	Token   string
+	Project string
+	Export  lokalise.ExportOptions
+	Import  lokalise.ImportOptions
+}
+
 func main() {
 	var apiToken string
 	var configFile string
 
-	type Config struct {
-		Token   string
-		Project string
-	}
-
 	app := cli.NewApp()
 	app.Name = "Lokalise CLI tool"
 	app.Version = "v0.72"
@@ -42,7 +45,7 @@ func main() {
 		},
 		cli.StringFlag{
 			Name:        "config",
-			Usage:       "Load configuration from `file`. Looks up /etc/lokalise.cfg by default.",
+			Usage:       "Load configuration from `file`. Looks up /etc/lokalise.cfg by default. Besides token and project the file may hold [export] and [import] tables with option defaults named like the command flags. Unlike the flags, boolean options take TOML booleans (true/false), not 0/1.",
 			Destination: &configFile,
 		},
 	}
@@ -53,14 +56,9 @@ func main() {
 			Aliases: []string{"l"},
 			Usage:   "List your projects at Lokalise.",
 			Action: func(c *cli.Context) error {
-				var conf Config
-
-				if configFile == "" {
-					configFile = "/etc/lokalise.cfg"
-				}
-
-				if _, err := toml.DecodeFile(configFile, &conf); err != nil {
-					// do nothing if no config
+				conf, err := loadConfig(configFile)
+				if err != nil {
+					return err
 				}
 
 				if apiToken == "" {
@@ -71,7 +69,10 @@ func main() {
 					return cli.NewExitError("ERROR: --token is required.  Run `lokalise help` for all options.", 5)
 				}
 
-				projects, err := lokalise.List(apiToken)
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				projects, err := lokalise.ListContext(ctx, apiToken)
 				if err != nil {
 					fmt.Printf("%v\n", err)
 					return cli.NewExitError("ERROR: API returned error (see above)", 7)
@@ -84,7 +85,7 @@ func main() {
 
 				for _, project := range projects {
 					cWhite.Print(project.ID)
-					if project.Owner == "1" {
+					if project.Role.IsAdmin() {
 						cGreen.Print(" (admin) ")
 					} else {
 						cRed.Print(" (contr) ")
@@ -102,7 +103,11 @@ func main() {
 			Flags: []cli.Flag{
 				cli.StringFlag{
 					Name:  "type",
-					Usage: "File format to export. See https://lokalise.co/apidocs#file_formats (single value, required)",
+					Usage: "File format to export. See https://lokalise.co/apidocs#file_formats (single value, required) " + allowed("type"),
+				},
+				cli.BoolFlag{
+					Name:  "preview",
+					Usage: "Print the file paths the export would produce without exporting.",
 				},
 				cli.StringFlag{
 					Name:  "dest",
@@ -126,7 +131,7 @@ func main() {
 				},
 				cli.StringFlag{
 					Name:  "filter",
-					Usage: "Filter by 'translated', 'reviewed', 'nonfuzzy', 'nonhidden', 'last_reviewed_only' fields. (comma separated)",
+					Usage: "Filter keys, comma separated. " + allowed("filter"),
 				},
 				cli.StringFlag{
 					Name:  "bundle_structure",
@@ -146,7 +151,7 @@ func main() {
 				},
 				cli.StringFlag{
 					Name:  "export_empty",
-					Usage: "How to export empty strings. (empty, base, skip)",
+					Usage: "How to export empty strings. " + allowed("export_empty"),
 				},
 				cli.StringFlag{
 					Name:  "include_comments",
@@ -182,15 +187,15 @@ func main() {
 				},
 				cli.StringFlag{
 					Name:  "java_properties_encoding",
-					Usage: "Encoding for .properties files. (utf-8, latin-1)",
+					Usage: "Encoding for .properties files. " + allowed("java_properties_encoding"),
 				},
 				cli.StringFlag{
 					Name:  "java_properties_separator",
-					Usage: "Separator for keys/values in .properties files. (=, :)",
+					Usage: "Separator for keys/values in .properties files. " + allowed("java_properties_separator"),
 				},
 				cli.StringFlag{
 					Name:  "export_sort",
-					Usage: "Key sort order. (first_added, last_added, last_updated, a_z, z_a)",
+					Usage: "Key sort order. " + allowed("export_sort"),
 				},
 				cli.StringFlag{
 					Name:  "replace_breaks",
@@ -202,7 +207,7 @@ func main() {
 				},
 				cli.StringFlag{
 					Name:  "triggers",
-					Usage: "Trigger integration export. Allowed values are 'amazons3', 'gcs', 'gitlab', 'github', 'bitbucket'. (comma separated)",
+					Usage: "Trigger integration export, comma separated. " + allowed("triggers"),
 				},
 				cli.StringFlag{
 					Name:  "repos",
@@ -210,7 +215,7 @@ func main() {
 				},
 				cli.StringFlag{
 					Name:  "plural_format",
-					Usage: "Override default plural format. See https://lokalise.co/apidocs#pl_ph_formats (value).",
+					Usage: "Override default plural format. See https://lokalise.co/apidocs#pl_ph_formats " + allowed("plural_format"),
 				},
 				cli.StringFlag{
 					Name:  "icu_numeric",
@@ -218,11 +223,11 @@ func main() {
 				},
 				cli.StringFlag{
 					Name:  "placeholder_format",
-					Usage: "Override default placeholder format. See https://lokalise.co/apidocs#pl_ph_formats (value).",
+					Usage: "Override default placeholder format. See https://lokalise.co/apidocs#pl_ph_formats " + allowed("placeholder_format"),
 				},
 				cli.StringFlag{
 					Name:  "indentation",
-					Usage: "Provide to override default indentation in supported files. (1sp, 2sp, 3sp, 4sp, 5sp, 6sp, 7sp, 8sp, tab)",
+					Usage: "Provide to override default indentation in supported files. " + allowed("indentation"),
 				},
 				cli.StringFlag{
 					Name:  "escape_percent",
@@ -230,14 +235,9 @@ func main() {
 				},
 			},
 			Action: func(c *cli.Context) error {
-				var conf Config
-
-				if configFile == "" {
-					configFile = "/etc/lokalise.cfg"
-				}
-
-				if _, err := toml.DecodeFile(configFile, &conf); err != nil {
-					// do nothing if no config
+				conf, err := loadConfig(configFile)
+				if err != nil {
+					return err
 				}
 
 				if apiToken == "" {
@@ -271,34 +271,56 @@ func main() {
 					c.Set("include_tags", legacyTags)
 				}
 
-				var opts []lokalise.ExportOption
-				opts = setExportBool(opts, c, "use_original", lokalise.WithOriginal)
-				opts = setExportString(opts, c, "bundle_structure", lokalise.WithBundleStructure)
-				opts = setExportString(opts, c, "directory_prefix", lokalise.WithDirectoryPrefix)
-				opts = setExportString(opts, c, "webhook_url", lokalise.WithWebhookURL)
-				opts = setExportBool(opts, c, "export_all", lokalise.WithAll)
-				opts = setExportString(opts, c, "export_empty", lokalise.WithEmpty)
-				opts = setExportString(opts, c, "export_sort", lokalise.WithSortOrder)
-				opts = setExportString(opts, c, "java_properties_encoding", lokalise.WithJavaPropertiesEncoding)
-				opts = setExportString(opts, c, "java_properties_separator", lokalise.WithJavaPropertiesSeparator)
-				opts = setExportString(opts, c, "placeholder_format", lokalise.WithPlaceholderFormat)
-				opts = setExportString(opts, c, "indentation", lokalise.WithIndentation)
-				opts = setExportString(opts, c, "plural_format", lokalise.WithPluralFormat)
-				opts = setExportBool(opts, c, "include_comments", lokalise.WithComments)
-				opts = setExportBool(opts, c, "include_description", lokalise.WithDescription)
-				opts = setExportBool(opts, c, "replace_breaks", lokalise.WithExportReplaceBreaks)
-				opts = setExportBool(opts, c, "yaml_include_root", lokalise.WithYAMLRoot)
-				opts = setExportBool(opts, c, "json_unescaped_slashes", lokalise.WithJSONUnescapedSlashes)
-				opts = setExportBool(opts, c, "no_language_folders", lokalise.WithNoLanguageFolders)
-				opts = setExportBool(opts, c, "icu_numeric", lokalise.WithICUNumeric)
-				opts = setExportBool(opts, c, "escape_percent", lokalise.WithPercentEscape)
-				opts = setExportStrings(opts, c, "langs", lokalise.WithLanguages)
-				opts = setExportStrings(opts, c, "filter", lokalise.WithFilter)
-				opts = setExportStrings(opts, c, "triggers", lokalise.WithTriggers)
-				opts = setExportStrings(opts, c, "repos", lokalise.WithRepos)
-				opts = setExportStrings(opts, c, "include_pids", lokalise.WithPIDs)
-				opts = setExportStrings(opts, c, "include_tags", lokalise.WithIncludeTags)
-				opts = setExportStrings(opts, c, "exclude_tags", lokalise.WithExcludeTags)
+				exportOpts := conf.Export
+				setBool(c, "use_original", &exportOpts.Original)
+				setString(c, "bundle_structure", &exportOpts.BundleStructure)
+				setOptionalString(c, "directory_prefix", &exportOpts.DirectoryPrefix)
+				setString(c, "webhook_url", &exportOpts.WebhookURL)
+				setBool(c, "export_all", &exportOpts.All)
+				setString(c, "export_empty", (*string)(&exportOpts.Empty))
+				setString(c, "export_sort", (*string)(&exportOpts.SortOrder))
+				setString(c, "java_properties_encoding", (*string)(&exportOpts.JavaPropertiesEncoding))
+				setString(c, "java_properties_separator", (*string)(&exportOpts.JavaPropertiesSeparator))
+				setString(c, "placeholder_format", (*string)(&exportOpts.PlaceholderFormat))
+				setString(c, "indentation", (*string)(&exportOpts.Indentation))
+				setString(c, "plural_format", (*string)(&exportOpts.PluralFormat))
+				setBool(c, "include_comments", &exportOpts.Comments)
+				setBool(c, "include_description", &exportOpts.Description)
+				setBool(c, "replace_breaks", &exportOpts.ReplaceBreaks)
+				setBool(c, "yaml_include_root", &exportOpts.YAMLRoot)
+				setBool(c, "json_unescaped_slashes", &exportOpts.JSONUnescapedSlashes)
+				setBool(c, "no_language_folders", &exportOpts.NoLanguageFolders)
+				setBool(c, "icu_numeric", &exportOpts.ICUNumeric)
+				setBool(c, "escape_percent", &exportOpts.PercentEscape)
+				setStrings(c, "langs", &exportOpts.Languages)
+				if filters := commaSlice(c.String("filter")); len(filters) != 0 {
+					exportOpts.Filter = nil
+					for _, filter := range filters {
+						exportOpts.Filter = append(exportOpts.Filter, lokalise.Filter(filter))
+					}
+				}
+				if triggers := commaSlice(c.String("triggers")); len(triggers) != 0 {
+					exportOpts.Triggers = nil
+					for _, trigger := range triggers {
+						exportOpts.Triggers = append(exportOpts.Triggers, lokalise.Trigger(trigger))
+					}
+				}
+				setStrings(c, "repos", &exportOpts.Repos)
+				setStrings(c, "include_pids", &exportOpts.PIDs)
+				setStrings(c, "include_tags", &exportOpts.IncludeTags)
+				setStrings(c, "exclude_tags", &exportOpts.ExcludeTags)
+				opts := exportOpts.Options()
+				warnings, err := lokalise.ValidateExport(fileType, opts...)
+				for _, w := range warnings {
+					color.New(color.FgRed).Println("WARNING: " + w.String())
+				}
+				if err != nil {
+					return cli.NewExitError("ERROR: "+err.Error(), 5)
+				}
+
+				if c.Bool("preview") {
+					return previewExport(apiToken, projectID, fileType, exportOpts)
+				}
 
 				unzipTo := c.String("unzip_to")
 				keepZip := c.String("keep_zip")
@@ -309,11 +331,14 @@ func main() {
 				cWhite := color.New(color.FgHiWhite)
 				cGreen := color.New(color.FgGreen)
 
+				ctx, cancel := interruptContext()
+				defer cancel()
+
 				theSpinner := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
 				theSpinner.Start()
 				fmt.Print("Requesting...")
 
-				bundle, err := lokalise.Export(apiToken, projectID, fileType, opts...)
+				bundle, err := lokalise.ExportContext(ctx, apiToken, projectID, fileType, opts...)
 				theSpinner.Stop()
 				if err != nil {
 					fmt.Printf("\n%v\n", err)
@@ -331,17 +356,25 @@ func main() {
 					cWhite.Print("Local ")
 					cGreen.Print(path.Join(dest, filename) + "... ")
 
-					downloadFile(path.Join(dest, filename), bundle.FullFile)
+					if _, err := lokalise.DownloadBundleFile(ctx, bundle, path.Join(dest, filename)); err != nil {
+						fmt.Printf("\n%v\n", err)
+						return cli.NewExitError("ERROR: bundle download failed (see above)", 7)
+					}
 					cWhite.Println("OK")
 
 					if unzipTo != "" {
-						files, err := unzip(path.Join(dest, filename), unzipTo)
+						files, err := lokalise.ExtractBundleFile(path.Join(dest, filename), unzipTo)
 
 						if err != nil {
 							cWhite.Println("Error unzipping files")
+							fmt.Printf("%v\n", err)
 						} else {
+							var paths []string
+							for _, f := range files {
+								paths = append(paths, f.Path)
+							}
 							cWhite.Print("Unzipped ")
-							cGreen.Print(strings.Join(files, ", ") + " ")
+							cGreen.Print(strings.Join(paths, ", ") + " ")
 							cWhite.Println("OK")
 							if keepZip == "0" {
 								os.Remove(path.Join(dest, filename))
@@ -429,16 +462,15 @@ func main() {
 					Name:  "cleanup_mode",
 					Usage: "Enable to delete keys with all language translations from Lokalise that are not present in the uploaded files. (`0/1`)",
 				},
+				cli.BoolFlag{
+					Name:  "snapshot-before",
+					Usage: "Take a project snapshot before uploading with --replace or --cleanup_mode.",
+				},
 			},
 			Action: func(c *cli.Context) error {
-				var conf Config
-
-				if configFile == "" {
-					configFile = "/etc/lokalise.cfg"
-				}
-
-				if _, err := toml.DecodeFile(configFile, &conf); err != nil {
-					// do nothing if no config
+				conf, err := loadConfig(configFile)
+				if err != nil {
+					return err
 				}
 
 				if apiToken == "" {
@@ -468,27 +500,30 @@ func main() {
 
 				includePath, _ := strconv.ParseBool(c.String("include_path"))
 
-				var opts []lokalise.ImportOption
-				opts = setImportBool(opts, c, "replace", lokalise.WithReplace)
-				opts = setImportBool(opts, c, "skip_detect_lang_iso", lokalise.WithSkipDetectLangIso)
-				opts = setImportBool(opts, c, "convert_placeholders", lokalise.WithConvertPlaceholders)
-				opts = setImportBool(opts, c, "icu_plurals", lokalise.WithICUPlurals)
-				opts = setImportBool(opts, c, "fill_empty", lokalise.WithFillEmpty)
-				opts = setImportBool(opts, c, "distinguish", lokalise.WithDistinguish)
-				opts = setImportBool(opts, c, "hidden", lokalise.WithHidden)
-				opts = setImportBool(opts, c, "use_trans_mem", lokalise.WithTranslationMemory)
-				opts = setImportStrings(opts, c, "tags", lokalise.WithTags)
-				opts = setImportStrings(opts, c, "tag_inserted_keys", lokalise.WithTagInsertedKeys)
-				opts = setImportStrings(opts, c, "tag_updated_keys", lokalise.WithTagUpdatedKeys)
-				opts = setImportStrings(opts, c, "tag_skipped_keys", lokalise.WithTagSkippedKeys)
-				opts = setImportBool(opts, c, "replace_breaks", lokalise.WithImportReplaceBreaks)
-				opts = setImportBool(opts, c, "cleanup_mode", lokalise.WithCleanupMode)
-
-				cWhite := color.New(color.FgHiWhite)
-				cGreen := color.New(color.FgGreen)
-
-				fileMasks := strings.Split(file, ",")
-				for _, mask := range fileMasks {
+				importOpts := conf.Import
+				setBool(c, "replace", &importOpts.Replace)
+				setBool(c, "skip_detect_lang_iso", &importOpts.SkipDetectLangIso)
+				setBool(c, "convert_placeholders", &importOpts.ConvertPlaceholders)
+				setBool(c, "icu_plurals", &importOpts.ICUPlurals)
+				setBool(c, "fill_empty", &importOpts.FillEmpty)
+				setBool(c, "distinguish", &importOpts.Distinguish)
+				setBool(c, "hidden", &importOpts.Hidden)
+				setBool(c, "use_trans_mem", &importOpts.TranslationMemory)
+				setStrings(c, "tags", &importOpts.Tags)
+				setStrings(c, "tag_inserted_keys", &importOpts.TagInsertedKeys)
+				setStrings(c, "tag_updated_keys", &importOpts.TagUpdatedKeys)
+				setStrings(c, "tag_skipped_keys", &importOpts.TagSkippedKeys)
+				setBool(c, "replace_breaks", &importOpts.ReplaceBreaks)
+				setBool(c, "cleanup_mode", &importOpts.CleanupMode)
+
+				// Resolve and validate all files first, so that a typo or an
+				// invalid option fails before anything changes the project.
+				type upload struct {
+					filename string
+					opts     []lokalise.ImportOption
+				}
+				var uploads []upload
+				for _, mask := range strings.Split(file, ",") {
 					files, err := filepath.Glob(mask)
 					if err != nil {
 						return cli.NewExitError("ERROR: file glob pattern not valid", 5)
@@ -496,150 +531,218 @@ func main() {
 
 					for _, filename := range files {
 						if includePath {
-							opts = append(opts, lokalise.WithFilename(filename))
+							importOpts.Filename = filename
+						}
+						opts := importOpts.Options()
+						warnings, err := lokalise.ValidateImport(filename, opts...)
+						for _, w := range warnings {
+							color.New(color.FgRed).Println("WARNING: " + w.String())
 						}
-						theSpinner := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
-						cWhite.Printf("Uploading %s... ", filename)
-						theSpinner.Start()
-						result, err := lokalise.Import(apiToken, projectID, filename, langIso, opts...)
-						theSpinner.Stop()
 						if err != nil {
-							fmt.Printf("\n%v\n", err)
-							return cli.NewExitError("ERROR: API returned error (see above)", 7)
+							return cli.NewExitError("ERROR: "+err.Error(), 5)
+						}
+						uploads = append(uploads, upload{filename: filename, opts: opts})
+					}
+				}
+
+				cWhite := color.New(color.FgHiWhite)
+				cGreen := color.New(color.FgGreen)
+
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				var snapshot *lokalise.Snapshot
+				destructive := (importOpts.Replace != nil && *importOpts.Replace) || (importOpts.CleanupMode != nil && *importOpts.CleanupMode)
+				if c.Bool("snapshot-before") && len(uploads) > 0 {
+					if destructive {
+						var err error
+						snapshot, err = snapshotBeforeImport(ctx, apiToken, projectID)
+						if err != nil {
+							return err
+						}
+					} else {
+						fmt.Println("Skipping snapshot, neither --replace nor --cleanup_mode is enabled.")
+					}
+				}
+
+				for _, u := range uploads {
+					theSpinner := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
+					cWhite.Printf("Uploading %s... ", u.filename)
+					theSpinner.Start()
+					result, err := lokalise.ImportContext(ctx, apiToken, projectID, u.filename, langIso, u.opts...)
+					theSpinner.Stop()
+					if err != nil {
+						fmt.Printf("\n%v\n", err)
+						if snapshot != nil {
+							printRestoreHint(projectID, *snapshot)
 						}
-						cGreen.Print("Inserted ")
-						cWhite.Print(result.Inserted)
-						cGreen.Print(", skipped ")
-						cWhite.Print(result.Skipped)
-						cGreen.Print(", updated ")
-						cWhite.Print(result.Updated)
-						cGreen.Println(" keys.")
+						return cli.NewExitError("ERROR: API returned error (see above)", 7)
 					}
+					cGreen.Print("Inserted ")
+					cWhite.Print(result.Inserted)
+					cGreen.Print(", skipped ")
+					cWhite.Print(result.Skipped)
+					cGreen.Print(", updated ")
+					cWhite.Print(result.Updated)
+					cGreen.Println(" keys.")
 				}
 
 				return nil
 			},
 		},
+		languagesCommand,
+		keysCommand,
+		translationCommand,
+		snapshotsCommand,
+		contributorsCommand,
+		screenshotsCommand,
+		tasksCommand,
 	}
 
 	app.Run(os.Args)
 }
 
-func downloadFile(filepath string, url string) (err error) {
-	out, err := os.Create(filepath)
+// interruptContext returns a context that is cancelled when the process
+// receives an interrupt, so that pending API calls are aborted.
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	go func() {
+		select {
+		case <-sig:
+			cancel()
+		case <-ctx.Done():
+		}
+		signal.Stop(sig)
+	}()
+	return ctx, cancel
+}
+
+// defaultConfigFile is the configuration file read unless --config is set.
+var defaultConfigFile = "/etc/lokalise.cfg"
+
+// loadConfig reads the configuration file, defaultConfigFile unless set.
+// If the default file cannot be read, e.g. because it is missing, the
+// configuration is empty. A file set explicitly must be readable, and any
+// file that is read must be valid.
+func loadConfig(configFile string) (Config, error) {
+	var conf Config
+	explicit := configFile != ""
+	if !explicit {
+		configFile = defaultConfigFile
+	}
+	data, err := ioutil.ReadFile(configFile)
 	if err != nil {
-		return err
+		if !explicit {
+			return conf, nil
+		}
+		return Config{}, cli.NewExitError(fmt.Sprintf("ERROR: Cannot load config %s: %v", configFile, err), 5)
+	}
+	if _, err := toml.Decode(string(data), &conf); err != nil {
+		return Config{}, cli.NewExitError(fmt.Sprintf("ERROR: Cannot load config %s: %v", configFile, err), 5)
 	}
-	defer out.Close()
-	resp, err := http.Get(url)
+	return conf, nil
+}
+
+// projectClient loads the configuration and returns a client for the API
+// token and the ID of the project given as first command argument.
+func projectClient(c *cli.Context) (*lokalise.Client, string, error) {
+	conf, err := loadConfig(c.GlobalString("config"))
 	if err != nil {
-		return err
+		return nil, "", err
+	}
+
+	apiToken := c.GlobalString("token")
+	if apiToken == "" {
+		apiToken = conf.Token
+	}
+	if apiToken == "" {
+		return nil, "", cli.NewExitError("ERROR: --token is required.  Run `lokalise help` for all options.", 5)
+	}
+	projectID := c.Args().First()
+	if projectID == "" {
+		projectID = conf.Project
 	}
-	defer resp.Body.Close()
-	_, err = io.Copy(out, resp.Body)
+	if projectID == "" {
+		return nil, "", cli.NewExitError("ERROR: Project ID is required as first command option. Run `lokalise help` for all options.", 5)
+	}
+	client, err := lokalise.NewClient(lokalise.WithAPIToken(apiToken))
 	if err != nil {
-		return err
+		return nil, "", cli.NewExitError("ERROR: "+err.Error(), 5)
 	}
+	return client, projectID, nil
+}
 
-	return nil
+// apiError prints err and returns the exit error for failed API requests.
+func apiError(err error) error {
+	fmt.Printf("%v\n", err)
+	return cli.NewExitError("ERROR: API returned error (see above)", 7)
 }
 
-func setExportBool(opts []lokalise.ExportOption, c *cli.Context, cmdField string, f func(v bool) lokalise.ExportOption) []lokalise.ExportOption {
-	value := c.String(cmdField)
-	if value == "" {
-		return opts
+// previewExport prints the paths an export of the project would produce.
+func previewExport(apiToken, projectID, fileType string, exportOpts lokalise.ExportOptions) error {
+	client, err := lokalise.NewClient(lokalise.WithAPIToken(apiToken))
+	if err != nil {
+		return cli.NewExitError("ERROR: "+err.Error(), 5)
 	}
-	b, _ := strconv.ParseBool(value)
-	return append(opts, f(b))
-}
-func setExportString(opts []lokalise.ExportOption, c *cli.Context, cmdField string, f func(v string) lokalise.ExportOption) []lokalise.ExportOption {
-	value := c.String(cmdField)
-	if value == "" {
-		return opts
+	ctx, cancel := interruptContext()
+	defer cancel()
+
+	project, err := client.GetProject(ctx, projectID)
+	if err != nil {
+		return apiError(err)
 	}
-	return append(opts, f(value))
-}
-func setExportStrings(opts []lokalise.ExportOption, c *cli.Context, cmdField string, f func(v ...string) lokalise.ExportOption) []lokalise.ExportOption {
-	value := commaSlice(c.String(cmdField))
-	if len(value) == 0 {
-		return opts
+	paths, err := lokalise.PreviewBundle(project.Name, fileType, project.Languages, exportOpts.Options()...)
+	if err != nil {
+		return cli.NewExitError("ERROR: "+err.Error(), 5)
 	}
-	return append(opts, f(value...))
-}
-
-func commaSlice(v string) []string {
-	if v == "" {
-		return nil
+	cGreen := color.New(color.FgGreen)
+	for _, p := range paths {
+		cGreen.Print(p.Language + " ")
+		fmt.Println(p.Path)
 	}
-	return strings.Split(v, ",")
+	return nil
 }
 
-func setImportBool(opts []lokalise.ImportOption, c *cli.Context, cmdField string, f func(v bool) lokalise.ImportOption) []lokalise.ImportOption {
+func setBool(c *cli.Context, cmdField string, dst **bool) {
 	value := c.String(cmdField)
 	if value == "" {
-		return opts
+		return
 	}
 	b, _ := strconv.ParseBool(value)
-	return append(opts, f(b))
+	*dst = &b
 }
-func setImportString(opts []lokalise.ImportOption, c *cli.Context, cmdField string, f func(v string) lokalise.ImportOption) []lokalise.ImportOption {
+func setString(c *cli.Context, cmdField string, dst *string) {
 	value := c.String(cmdField)
-	if len(value) == 0 {
-		return opts
+	if value == "" {
+		return
 	}
-	return append(opts, f(value))
+	*dst = value
 }
-func setImportStrings(opts []lokalise.ImportOption, c *cli.Context, cmdField string, f func(v ...string) lokalise.ImportOption) []lokalise.ImportOption {
+func setOptionalString(c *cli.Context, cmdField string, dst **string) {
+	if !c.IsSet(cmdField) {
+		return
+	}
+	value := c.String(cmdField)
+	*dst = &value
+}
+func setStrings(c *cli.Context, cmdField string, dst *[]string) {
 	value := commaSlice(c.String(cmdField))
 	if len(value) == 0 {
-		return opts
+		return
 	}
-	return append(opts, f(value...))
+	*dst = value
 }
 
-func unzip(src, dest string) ([]string, error) {
-	var filenames []string
-
-	r, err := zip.OpenReader(src)
-	if err != nil {
-		return filenames, err
-	}
-	defer r.Close()
+// allowed returns the allowed values of the export option for flag usage texts.
+func allowed(option string) string {
+	return "(" + strings.Join(lokalise.AllowedValues(option), ", ") + ")"
+}
 
-	for _, f := range r.File {
-		rc, err := f.Open()
-		if err != nil {
-			return filenames, err
-		}
-		defer rc.Close()
-
-		fpath := filepath.Join(dest, f.Name)
-		filenames = append(filenames, fpath)
-
-		if f.FileInfo().IsDir() {
-			os.MkdirAll(fpath, os.ModePerm)
-		} else {
-			var fdir string
-			if lastIndex := strings.LastIndex(fpath, string(os.PathSeparator)); lastIndex > -1 {
-				fdir = fpath[:lastIndex]
-			}
-
-			err = os.MkdirAll(fdir, os.ModePerm)
-			if err != nil {
-				return filenames, err
-			}
-			f, err := os.OpenFile(
-				fpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.Mode())
-			if err != nil {
-				return filenames, err
-			}
-			defer f.Close()
-
-			_, err = io.Copy(f, rc)
-			if err != nil {
-				return filenames, err
-			}
-		}
+func commaSlice(v string) []string {
+	if v == "" {
+		return nil
 	}
-	return filenames, nil
+	return strings.Split(v, ",")
 }
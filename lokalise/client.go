@@ -0,0 +1,292 @@
+package lokalise
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const defaultUserAgent = "lokalise-cli-go"
+
+// Client is a Lokalise API client. A Client is safe for concurrent use by
+// multiple goroutines and should be reused so that the underlying HTTP
+// connections are pooled.
+//
+// Use NewClient to create a Client with any ClientOptions.
+type Client struct {
+	apiToken   string
+	baseURL    string
+	assetURL   string
+	userAgent  string
+	httpClient *http.Client
+	transport  http.RoundTripper
+	timeout    *time.Duration
+	retry      RetryPolicy
+	limiter    *limiter
+	middleware []Middleware
+	roundTrip  RoundTripFunc
+	fetch      RoundTripFunc
+}
+
+// ClientOption is a function setting options for a Client.
+type ClientOption func(*Client) error
+
+// NewClient returns a Client configured with opts. Without options the
+// Client talks to the public Lokalise API with a timeout of 120 seconds and
+// retries failed requests according to DefaultRetryPolicy.
+func NewClient(opts ...ClientOption) (*Client, error) {
+	c := &Client{
+		baseURL:   baseURL,
+		assetURL:  assetURL,
+		userAgent: defaultUserAgent,
+		retry:     DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		err := opt(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+	hc := http.Client{Timeout: timeout}
+	if c.httpClient != nil {
+		hc = *c.httpClient
+	}
+	if c.timeout != nil {
+		hc.Timeout = *c.timeout
+	}
+	if c.transport != nil {
+		hc.Transport = c.transport
+	}
+	c.httpClient = &hc
+	c.roundTrip = c.chain(c.httpClient.Do)
+	// Bundles may take longer to download than any API request, so
+	// downloads are bounded by their context only.
+	dc := hc
+	dc.Timeout = 0
+	c.fetch = c.chain(dc.Do)
+	return c, nil
+}
+
+// WithAPIToken returns a ClientOption setting the API token used to
+// authenticate every request.
+func WithAPIToken(apiToken string) ClientOption {
+	return func(c *Client) error {
+		c.apiToken = apiToken
+		return nil
+	}
+}
+
+// WithBaseURL returns a ClientOption overriding the API base URL. Useful for
+// pointing the Client at a proxy or a local stand-in server.
+//
+// Example:
+//   http://127.0.0.1:8080/api/
+func WithBaseURL(u string) ClientOption {
+	return func(c *Client) error {
+		if u == "" {
+			return errors.New("lokalise: base URL must not be empty")
+		}
+		c.baseURL = withTrailingSlash(u)
+		return nil
+	}
+}
+
+// WithAssetURL returns a ClientOption overriding the URL export bundles are
+// downloaded from.
+func WithAssetURL(u string) ClientOption {
+	return func(c *Client) error {
+		if u == "" {
+			return errors.New("lokalise: asset URL must not be empty")
+		}
+		c.assetURL = withTrailingSlash(u)
+		return nil
+	}
+}
+
+// WithHTTPClient returns a ClientOption setting the http.Client used to send
+// requests. The http.Client is copied, so later changes to it have no effect.
+//
+// If set, the timeout of the http.Client is used unless WithTimeout is set
+// as well.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) error {
+		if hc == nil {
+			return errors.New("lokalise: http client must not be nil")
+		}
+		c.httpClient = hc
+		return nil
+	}
+}
+
+// WithTransport returns a ClientOption setting the http.RoundTripper used to
+// send requests. It takes precedence over the transport of a http.Client set
+// with WithHTTPClient.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) error {
+		if rt == nil {
+			return errors.New("lokalise: transport must not be nil")
+		}
+		c.transport = rt
+		return nil
+	}
+}
+
+// WithTimeout returns a ClientOption setting the timeout of each API request.
+// A timeout of zero means no timeout. Bundle downloads are not subject to the
+// timeout, use their context to limit them.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) error {
+		if d < 0 {
+			return errors.New("lokalise: timeout must not be negative")
+		}
+		c.timeout = &d
+		return nil
+	}
+}
+
+// WithUserAgent returns a ClientOption setting the User-Agent header sent
+// with every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// DefaultClient is the Client used by the package level functions. It is
+// shared so that connections are pooled between calls. Tests may replace it,
+// e.g. with a Client talking to a lokalisetest.Server.
+var DefaultClient, _ = NewClient()
+
+// withToken returns a shallow copy of c authenticating with apiToken.
+func (c *Client) withToken(apiToken string) *Client {
+	cc := *c
+	cc.apiToken = apiToken
+	return &cc
+}
+
+func (c *Client) callAPI(req *http.Request) (*http.Response, error) {
+	if c.limiter != nil {
+		if err := c.limiter.wait(req.Context()); err != nil {
+			// Release a streaming body that is never going to be sent.
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, err
+		}
+	}
+	return c.send(c.roundTrip, req)
+}
+
+// send sends req through rt, one of the middleware chains of the Client.
+func (c *Client) send(rt RoundTripFunc, req *http.Request) (*http.Response, error) {
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	return rt(req)
+}
+
+// formRequest returns a request builder for do posting form as an URL encoded
+// body. The API token is added to the body, so it never appears in URLs.
+func (c *Client) formRequest(ctx context.Context, form url.Values) func(u string) (*http.Request, error) {
+	body := url.Values{}
+	for k, v := range form {
+		body[k] = v
+	}
+	body.Set("api_token", c.apiToken)
+	encoded := body.Encode()
+	return func(u string) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(encoded))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req, nil
+	}
+}
+
+// doFunc is the signature of do and doUnlessProcessed, for helpers shared by
+// requests with different retry rules.
+type doFunc func(ctx context.Context, endpoint string, newRequest func(u string) (*http.Request, error), v interface{}) error
+
+// do sends the request returned by newRequest for the API endpoint and decodes
+// the JSON response into v. The request is rebuilt for every attempt, so
+// newRequest must return a request with a fresh body each time it is called.
+func (c *Client) do(ctx context.Context, endpoint string, newRequest func(u string) (*http.Request, error), v interface{}) error {
+	return c.retryRequest(ctx, endpoint, newRequest, v, IsRetryable)
+}
+
+// doUnlessProcessed is like do but only retries the request if the API
+// certainly did not process it, see isRejected. It is used for requests that
+// create or remove a resource, e.g. a key or a snapshot: repeating those
+// after an ambiguous failure like a 5xx status could create the resource
+// twice or fail on the resource removed by the first attempt.
+func (c *Client) doUnlessProcessed(ctx context.Context, endpoint string, newRequest func(u string) (*http.Request, error), v interface{}) error {
+	return c.retryRequest(ctx, endpoint, newRequest, v, isRejected)
+}
+
+func (c *Client) retryRequest(ctx context.Context, endpoint string, newRequest func(u string) (*http.Request, error), v interface{}, retryable func(error) bool) error {
+	for attempt := 1; ; attempt++ {
+		wait, err := c.attempt(endpoint, newRequest, v)
+		if err == nil {
+			return nil
+		}
+		if !retryable(err) || attempt >= c.retry.MaxAttempts || ctx.Err() != nil {
+			return c.redactError(err)
+		}
+		if sleep(ctx, c.retry.delay(attempt, wait)) != nil {
+			return c.redactError(err)
+		}
+	}
+}
+
+// attempt performs a single round trip. On failure it returns the delay
+// requested by the API through a Retry-After header, if any.
+func (c *Client) attempt(endpoint string, newRequest func(u string) (*http.Request, error), v interface{}) (time.Duration, error) {
+	req, err := newRequest(c.api(endpoint))
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.callAPI(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	wait, _ := retryAfter(resp.Header)
+	if err := errorFromStatus(resp, c.apiToken); err != nil {
+		return wait, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	var dat struct {
+		Response response `json:"response"`
+	}
+	if err := json.Unmarshal(body, &dat); err != nil {
+		return 0, decodeError(endpoint, err)
+	}
+	if err := errorFromResponse(dat.Response, c.apiToken); err != nil {
+		return wait, err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return 0, decodeError(endpoint, err)
+	}
+	return 0, nil
+}
+
+func (c *Client) api(path string) string {
+	return c.baseURL + path
+}
+
+func withTrailingSlash(u string) string {
+	if strings.HasSuffix(u, "/") {
+		return u
+	}
+	return u + "/"
+}
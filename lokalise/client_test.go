@@ -0,0 +1,703 @@
+package lokalise_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+const (
+	projectID = "123.abc"
+	apiToken  = "secret-token"
+
+	projectsBody     = `{"projects":[{"id":"123.abc","name":"App"}],"response":{"status":"success","code":"200","message":"OK"}}`
+	importBody       = `{"result":{"skipped":1,"inserted":1,"updated":0},"response":{"status":"success","code":"200","message":"OK"}}`
+	rateLimitBody    = `{"response":{"status":"error","code":"4048","message":"Too many requests"}}`
+	accessDeniedBody = `{"response":{"status":"error","code":"403","message":"Access denied"}}`
+)
+
+// reply is a canned response of a scripted server.
+type reply struct {
+	status     int
+	retryAfter string
+	body       string
+}
+
+// received is a request received by a scripted server.
+type received struct {
+	URL           string
+	Header        http.Header
+	ContentLength int64
+	Form          url.Values
+	Filename      string
+	File          []byte
+}
+
+// scripted is an API server answering requests with its replies in order,
+// repeating the last one.
+type scripted struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	replies  []reply
+	requests []received
+}
+
+func newScripted(replies ...reply) *scripted {
+	s := &scripted{replies: replies}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *scripted) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	req := received{URL: r.URL.String(), Header: r.Header, ContentLength: r.ContentLength}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.Form = url.Values(r.MultipartForm.Value)
+		if files := r.MultipartForm.File["file"]; len(files) > 0 {
+			req.Filename = files[0].Filename
+			f, err := files[0].Open()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			req.File, _ = ioutil.ReadAll(f)
+			f.Close()
+		}
+	} else {
+		r.ParseForm()
+		req.Form = r.PostForm
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	rep := s.replies[0]
+	if len(s.replies) > 1 {
+		s.replies = s.replies[1:]
+	}
+	s.mu.Unlock()
+
+	if rep.retryAfter != "" {
+		w.Header().Set("Retry-After", rep.retryAfter)
+	}
+	if rep.status == 0 {
+		rep.status = http.StatusOK
+	}
+	w.WriteHeader(rep.status)
+	w.Write([]byte(rep.body))
+}
+
+// received returns the requests the server received so far.
+func (s *scripted) received() []received {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]received(nil), s.requests...)
+}
+
+// fastRetries retries up to three times without noticeable delay.
+var fastRetries = lokalise.WithRetryPolicy(lokalise.RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  time.Millisecond,
+	MaxBackoff:  time.Millisecond,
+})
+
+// newTestClient returns a Client talking to srv.
+func newTestClient(t *testing.T, srv *httptest.Server, opts ...lokalise.ClientOption) *lokalise.Client {
+	t.Helper()
+	opts = append([]lokalise.ClientOption{lokalise.WithBaseURL(srv.URL), lokalise.WithAPIToken(apiToken)}, opts...)
+	c, err := lokalise.NewClient(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// recordingTransport records the requests it sends.
+type recordingTransport struct {
+	requests []*http.Request
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.requests = append(rt.requests, req)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// optionEnv is the environment a TestClientOptions case is run in.
+type optionEnv struct {
+	url          string
+	hc           *http.Client
+	hcTransport  *recordingTransport
+	optTransport *recordingTransport
+}
+
+func TestClientOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/slow/") {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte(projectsBody))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name string
+		opts func(e *optionEnv) []lokalise.ClientOption
+		// after runs once the Client is created.
+		after         func(e *optionEnv)
+		wantErr       bool
+		wantPath      string
+		wantUserAgent string
+		// wantVia is the transport expected to send the request.
+		wantVia func(e *optionEnv) *recordingTransport
+	}{
+		{
+			name: "defaults",
+			opts: func(e *optionEnv) []lokalise.ClientOption {
+				return []lokalise.ClientOption{lokalise.WithTransport(e.optTransport)}
+			},
+			wantPath:      "/project/list",
+			wantUserAgent: "lokalise-cli-go",
+			wantVia:       func(e *optionEnv) *recordingTransport { return e.optTransport },
+		},
+		{
+			name: "user agent",
+			opts: func(e *optionEnv) []lokalise.ClientOption {
+				return []lokalise.ClientOption{lokalise.WithTransport(e.optTransport), lokalise.WithUserAgent("app/1.0")}
+			},
+			wantPath:      "/project/list",
+			wantUserAgent: "app/1.0",
+			wantVia:       func(e *optionEnv) *recordingTransport { return e.optTransport },
+		},
+		{
+			name: "base URL without trailing slash",
+			opts: func(e *optionEnv) []lokalise.ClientOption {
+				return []lokalise.ClientOption{lokalise.WithTransport(e.optTransport), lokalise.WithBaseURL(e.url + "/api")}
+			},
+			wantPath:      "/api/project/list",
+			wantUserAgent: "lokalise-cli-go",
+			wantVia:       func(e *optionEnv) *recordingTransport { return e.optTransport },
+		},
+		{
+			name: "http client transport",
+			opts: func(e *optionEnv) []lokalise.ClientOption {
+				return []lokalise.ClientOption{lokalise.WithHTTPClient(e.hc)}
+			},
+			wantPath:      "/project/list",
+			wantUserAgent: "lokalise-cli-go",
+			wantVia:       func(e *optionEnv) *recordingTransport { return e.hcTransport },
+		},
+		{
+			name: "transport takes precedence over http client",
+			opts: func(e *optionEnv) []lokalise.ClientOption {
+				return []lokalise.ClientOption{lokalise.WithHTTPClient(e.hc), lokalise.WithTransport(e.optTransport)}
+			},
+			wantPath:      "/project/list",
+			wantUserAgent: "lokalise-cli-go",
+			wantVia:       func(e *optionEnv) *recordingTransport { return e.optTransport },
+		},
+		{
+			name: "http client is copied",
+			opts: func(e *optionEnv) []lokalise.ClientOption {
+				return []lokalise.ClientOption{lokalise.WithHTTPClient(e.hc)}
+			},
+			after:         func(e *optionEnv) { e.hc.Transport = e.optTransport },
+			wantPath:      "/project/list",
+			wantUserAgent: "lokalise-cli-go",
+			wantVia:       func(e *optionEnv) *recordingTransport { return e.hcTransport },
+		},
+		{
+			name: "http client timeout",
+			opts: func(e *optionEnv) []lokalise.ClientOption {
+				e.hc.Timeout = 20 * time.Millisecond
+				return []lokalise.ClientOption{lokalise.WithHTTPClient(e.hc), lokalise.WithBaseURL(e.url + "/slow/")}
+			},
+			wantErr: true,
+		},
+		{
+			name: "timeout overrides http client timeout",
+			opts: func(e *optionEnv) []lokalise.ClientOption {
+				e.hc.Timeout = 20 * time.Millisecond
+				return []lokalise.ClientOption{
+					lokalise.WithHTTPClient(e.hc),
+					lokalise.WithBaseURL(e.url + "/slow/"),
+					lokalise.WithTimeout(10 * time.Second),
+				}
+			},
+			wantPath:      "/slow/project/list",
+			wantUserAgent: "lokalise-cli-go",
+			wantVia:       func(e *optionEnv) *recordingTransport { return e.hcTransport },
+		},
+		{
+			name: "timeout",
+			opts: func(e *optionEnv) []lokalise.ClientOption {
+				return []lokalise.ClientOption{
+					lokalise.WithTransport(e.optTransport),
+					lokalise.WithBaseURL(e.url + "/slow/"),
+					lokalise.WithTimeout(20 * time.Millisecond),
+				}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &optionEnv{url: srv.URL, hcTransport: &recordingTransport{}, optTransport: &recordingTransport{}}
+			e.hc = &http.Client{Transport: e.hcTransport}
+			opts := append([]lokalise.ClientOption{
+				lokalise.WithBaseURL(srv.URL),
+				lokalise.WithRetryPolicy(lokalise.RetryPolicy{MaxAttempts: 1}),
+			}, tt.opts(e)...)
+			c, err := lokalise.NewClient(opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.after != nil {
+				tt.after(e)
+			}
+
+			_, err = c.List(context.Background())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("got no error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			via := tt.wantVia(e)
+			if n := len(e.hcTransport.requests) + len(e.optTransport.requests); n != 1 || len(via.requests) != 1 {
+				t.Fatalf("sent %d requests, want 1 through the expected transport", n)
+			}
+			req := via.requests[0]
+			if req.URL.Path != tt.wantPath {
+				t.Errorf("path = %s, want %s", req.URL.Path, tt.wantPath)
+			}
+			if got := req.Header.Get("User-Agent"); got != tt.wantUserAgent {
+				t.Errorf("User-Agent = %q, want %q", got, tt.wantUserAgent)
+			}
+		})
+	}
+}
+
+func TestContextCanceled(t *testing.T) {
+	arrived := make(chan struct{}, 1)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Hold the request without reading its body.
+		arrived <- struct{}{}
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+	c, err := lokalise.NewClient(lokalise.WithBaseURL(srv.URL), lokalise.WithAPIToken("secret-token"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The upload exceeds the connection buffers, so it is still being sent
+	// when the import is cancelled.
+	dir, err := ioutil.TempDir("", "lokalise")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "en.json")
+	if err := ioutil.WriteFile(file, []byte(strings.Repeat(" ", 16<<20)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		call func(ctx context.Context) error
+	}{
+		{"list", func(ctx context.Context) error {
+			_, err := c.List(ctx)
+			return err
+		}},
+		{"export", func(ctx context.Context) error {
+			_, err := c.Export(ctx, "123.abc", "json")
+			return err
+		}},
+		{"import", func(ctx context.Context) error {
+			_, err := c.Import(ctx, "123.abc", file, "en")
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				<-arrived
+				cancel()
+			}()
+			if err := tt.call(ctx); !errors.Is(err, context.Canceled) {
+				t.Errorf("error = %v, want context.Canceled", err)
+			}
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	srv := newScripted(
+		reply{status: http.StatusBadGateway},
+		reply{body: rateLimitBody},
+		reply{body: projectsBody},
+	)
+	defer srv.Close()
+	c := newTestClient(t, srv.Server, fastRetries)
+
+	projects, err := c.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 1 || projects[0].ID != projectID {
+		t.Errorf("projects = %+v, want project %s", projects, projectID)
+	}
+	if n := len(srv.received()); n != 3 {
+		t.Errorf("got %d attempts, want 3", n)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	srv := newScripted(reply{status: http.StatusServiceUnavailable})
+	defer srv.Close()
+	c := newTestClient(t, srv.Server, fastRetries)
+
+	if _, err := c.List(context.Background()); err == nil {
+		t.Fatal("got no error")
+	}
+	if n := len(srv.received()); n != 3 {
+		t.Errorf("got %d attempts, want 3", n)
+	}
+}
+
+func TestNoRetry(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply reply
+		opts  []lokalise.ClientOption
+	}{
+		{"permanent error", reply{body: accessDeniedBody}, []lokalise.ClientOption{fastRetries}},
+		{"client error", reply{status: http.StatusNotFound}, []lokalise.ClientOption{fastRetries}},
+		{"retries disabled", reply{status: http.StatusBadGateway}, []lokalise.ClientOption{
+			lokalise.WithRetryPolicy(lokalise.RetryPolicy{MaxAttempts: 1}),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newScripted(tt.reply, reply{body: projectsBody})
+			defer srv.Close()
+			c := newTestClient(t, srv.Server, tt.opts...)
+
+			if _, err := c.List(context.Background()); err == nil {
+				t.Fatal("got no error")
+			}
+			if n := len(srv.received()); n != 1 {
+				t.Errorf("got %d attempts, want 1", n)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	srv := newScripted(
+		reply{status: http.StatusServiceUnavailable, retryAfter: "1"},
+		reply{body: projectsBody},
+	)
+	defer srv.Close()
+	// The backoff alone would exceed the test timeout.
+	c := newTestClient(t, srv.Server, lokalise.WithRetryPolicy(lokalise.RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Hour,
+		MaxBackoff:  time.Hour,
+	}))
+
+	start := time.Now()
+	if _, err := c.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d < time.Second || d > time.Minute {
+		t.Errorf("took %v, want the delay of Retry-After", d)
+	}
+}
+
+func TestRetryAfterExceedsMaxBackoff(t *testing.T) {
+	srv := newScripted(
+		reply{status: http.StatusServiceUnavailable, retryAfter: "3600"},
+		reply{body: projectsBody},
+	)
+	defer srv.Close()
+	c := newTestClient(t, srv.Server, lokalise.WithRetryPolicy(lokalise.RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  100 * time.Millisecond,
+	}))
+
+	start := time.Now()
+	if _, err := c.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d < 100*time.Millisecond || d > 10*time.Second {
+		t.Errorf("took %v, want to wait MaxBackoff", d)
+	}
+	if n := len(srv.received()); n != 2 {
+		t.Errorf("got %d attempts, want 2", n)
+	}
+}
+
+func TestRetryPolicyDefaultBackoff(t *testing.T) {
+	srv := newScripted(
+		reply{status: http.StatusServiceUnavailable, retryAfter: "1"},
+		reply{body: projectsBody},
+	)
+	defer srv.Close()
+	// Without backoffs the policy falls back to those of DefaultRetryPolicy,
+	// which allow the delay asked for by Retry-After.
+	c := newTestClient(t, srv.Server, lokalise.WithRetryPolicy(lokalise.RetryPolicy{MaxAttempts: 2}))
+
+	start := time.Now()
+	if _, err := c.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d < time.Second {
+		t.Errorf("took %v, want the delay of Retry-After", d)
+	}
+	if n := len(srv.received()); n != 2 {
+		t.Errorf("got %d attempts, want 2", n)
+	}
+}
+
+func TestRetryCanceled(t *testing.T) {
+	srv := newScripted(reply{status: http.StatusServiceUnavailable})
+	defer srv.Close()
+	c := newTestClient(t, srv.Server, lokalise.WithRetryPolicy(lokalise.RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Hour,
+		MaxBackoff:  time.Hour,
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.List(ctx); err == nil {
+		t.Fatal("got no error")
+	}
+	if d := time.Since(start); d > time.Minute {
+		t.Errorf("took %v, want to stop waiting once the context is done", d)
+	}
+	if n := len(srv.received()); n != 1 {
+		t.Errorf("got %d attempts, want 1", n)
+	}
+}
+
+func TestImportRetryRebuildsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lokalise")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "en.json")
+	content := `{"greeting":"Hello","welcome":"Welcome"}`
+	if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := newScripted(reply{status: http.StatusBadGateway}, reply{body: importBody})
+	defer srv.Close()
+	c := newTestClient(t, srv.Server, fastRetries)
+
+	result, err := c.Import(context.Background(), projectID, file, "en", lokalise.WithReplace(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Inserted != 1 || result.Skipped != 1 {
+		t.Errorf("result = %+v, want 1 inserted and 1 skipped", result)
+	}
+	reqs := srv.received()
+	if len(reqs) != 2 {
+		t.Fatalf("got %d attempts, want 2", len(reqs))
+	}
+	for i, req := range reqs {
+		if req.Filename != "en.json" || string(req.File) != content {
+			t.Errorf("attempt %d uploaded %s %q, want en.json %q", i+1, req.Filename, req.File, content)
+		}
+		if req.Form.Get("id") != projectID || req.Form.Get("lang_iso") != "en" || req.Form.Get("replace") != "1" {
+			t.Errorf("attempt %d sent form %v", i+1, req.Form)
+		}
+	}
+}
+
+func TestNoRetryOnConfigurationError(t *testing.T) {
+	c, err := lokalise.NewClient(lokalise.WithBaseURL("htp://example.com/"), lokalise.WithAPIToken(apiToken), fastRetries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.List(context.Background())
+	if err == nil || lokalise.IsRetryable(err) {
+		t.Errorf("error = %v, want a permanent error", err)
+	}
+}
+
+func TestRetryCreate(t *testing.T) {
+	const created = `{"keys":[{"key_id":1,"key_name":"greeting"}],"response":{"status":"success","code":"200","message":"OK"}}`
+	keys := []lokalise.Key{{Name: "greeting", Platforms: []lokalise.Platform{lokalise.PlatformWeb}}}
+
+	t.Run("ambiguous failure", func(t *testing.T) {
+		// The API may have created the key before failing.
+		srv := newScripted(reply{status: http.StatusBadGateway}, reply{body: created})
+		defer srv.Close()
+		c := newTestClient(t, srv.Server, fastRetries)
+
+		if _, err := c.CreateKeys(context.Background(), projectID, keys); err == nil {
+			t.Fatal("got no error")
+		}
+		if n := len(srv.received()); n != 1 {
+			t.Errorf("got %d attempts, want 1", n)
+		}
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		srv := newScripted(reply{body: rateLimitBody}, reply{status: http.StatusTooManyRequests}, reply{body: created})
+		defer srv.Close()
+		c := newTestClient(t, srv.Server, fastRetries)
+
+		got, err := c.CreateKeys(context.Background(), projectID, keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 || got[0].ID != 1 {
+			t.Errorf("keys = %+v, want the created key", got)
+		}
+		if n := len(srv.received()); n != 3 {
+			t.Errorf("got %d attempts, want 3", n)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		srv := newScripted(reply{status: http.StatusBadGateway}, reply{body: created})
+		defer srv.Close()
+		c := newTestClient(t, srv.Server, fastRetries)
+
+		if _, err := c.UpdateKeys(context.Background(), projectID, []lokalise.Key{{ID: 1, Name: "greeting"}}); err != nil {
+			t.Fatal(err)
+		}
+		if n := len(srv.received()); n != 2 {
+			t.Errorf("got %d attempts, want 2", n)
+		}
+	})
+}
+
+func TestRetryUnlessProcessed(t *testing.T) {
+	const ok = `{"response":{"status":"success","code":"200","message":"OK"}}`
+	ctx := context.Background()
+	png := []byte("\x89PNG\r\n\x1a\n")
+
+	tests := []struct {
+		endpoint string
+		call     func(c *lokalise.Client) error
+		// retried tells whether an ambiguous failure is retried.
+		retried bool
+	}{
+		{"language/add", func(c *lokalise.Client) error {
+			_, err := c.AddLanguage(ctx, projectID, "fr")
+			return err
+		}, false},
+		{"language/update", func(c *lokalise.Client) error {
+			_, err := c.UpdateLanguage(ctx, projectID, "fr", lokalise.WithCustomName("French"))
+			return err
+		}, true},
+		{"language/remove", func(c *lokalise.Client) error {
+			return c.RemoveLanguage(ctx, projectID, "fr")
+		}, false},
+		{"key/delete", func(c *lokalise.Client) error {
+			return c.DeleteKeys(ctx, projectID, 1)
+		}, false},
+		{"snapshot/create", func(c *lokalise.Client) error {
+			_, err := c.CreateSnapshot(ctx, projectID, "before import")
+			return err
+		}, false},
+		{"snapshot/restore", func(c *lokalise.Client) error {
+			_, err := c.RestoreSnapshot(ctx, projectID, 1)
+			return err
+		}, false},
+		{"contributor/add", func(c *lokalise.Client) error {
+			_, err := c.InviteContributor(ctx, projectID, "ann@example.com")
+			return err
+		}, false},
+		{"contributor/update", func(c *lokalise.Client) error {
+			_, err := c.UpdateContributor(ctx, projectID, 1, lokalise.WithReviewer(true))
+			return err
+		}, true},
+		{"contributor/remove", func(c *lokalise.Client) error {
+			return c.RemoveContributor(ctx, projectID, 1)
+		}, false},
+		{"screenshot/upload", func(c *lokalise.Client) error {
+			_, err := c.UploadScreenshot(ctx, projectID, "home.png", png)
+			return err
+		}, false},
+		{"screenshot/delete", func(c *lokalise.Client) error {
+			return c.DeleteScreenshot(ctx, projectID, 1)
+		}, false},
+		{"task/create", func(c *lokalise.Client) error {
+			_, err := c.CreateTask(ctx, projectID, "Translate greeting", []int64{1}, []lokalise.TaskLanguage{{ISO: "de"}})
+			return err
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			// The API may have processed the request before failing.
+			srv := newScripted(reply{status: http.StatusBadGateway}, reply{body: ok})
+			defer srv.Close()
+			c := newTestClient(t, srv.Server, fastRetries)
+
+			err := tt.call(c)
+			want := 1
+			if tt.retried {
+				want = 2
+				if err != nil {
+					t.Fatal(err)
+				}
+			} else if err == nil {
+				t.Fatal("got no error")
+			}
+			got := srv.received()
+			if len(got) != want {
+				t.Fatalf("got %d attempts, want %d", len(got), want)
+			}
+			if !strings.HasSuffix(got[0].URL, "/"+tt.endpoint) {
+				t.Errorf("URL = %s, want endpoint %s", got[0].URL, tt.endpoint)
+			}
+
+			// Rate limited requests were certainly not processed.
+			srv = newScripted(reply{body: rateLimitBody}, reply{body: ok})
+			defer srv.Close()
+			c = newTestClient(t, srv.Server, fastRetries)
+
+			if err := tt.call(c); err != nil {
+				t.Fatal(err)
+			}
+			if n := len(srv.received()); n != 2 {
+				t.Errorf("got %d attempts, want 2", n)
+			}
+		})
+	}
+}
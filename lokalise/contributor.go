@@ -0,0 +1,143 @@
+package lokalise
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Contributor is the data model for a user working on a project.
+type Contributor struct {
+	ID       int64  `json:"user_id"`
+	Email    string `json:"email"`
+	Name     string `json:"fullname"`
+	Admin    bool   `json:"is_admin"`
+	Reviewer bool   `json:"is_reviewer"`
+	// Languages holds the languages the contributor has access to. Admins
+	// have access to all languages.
+	Languages []LanguagePermission `json:"languages"`
+}
+
+// Role returns the role of the contributor on the project.
+func (c Contributor) Role() Role {
+	if c.Admin {
+		return RoleAdmin
+	}
+	return RoleContributor
+}
+
+// LanguagePermission is the access of a contributor to a language.
+type LanguagePermission struct {
+	// ISO is the language code.
+	ISO string `json:"lang_iso"`
+	// Writable is false for read-only access.
+	Writable bool `json:"is_writable"`
+}
+
+// ContributorOption is a function setting options for inviting or updating a
+// contributor.
+type ContributorOption func(*url.Values) error
+
+// WithFullname returns a ContributorOption setting the name of an invited
+// contributor.
+func WithFullname(name string) ContributorOption {
+	return ContributorOption(stringField("fullname", name))
+}
+
+// WithAdmin returns a ContributorOption setting whether the contributor has
+// admin rights, which grant access to all languages.
+func WithAdmin(enabled bool) ContributorOption {
+	return ContributorOption(boolField("is_admin", enabled))
+}
+
+// WithReviewer returns a ContributorOption setting whether the contributor
+// may review translations.
+func WithReviewer(enabled bool) ContributorOption {
+	return ContributorOption(boolField("is_reviewer", enabled))
+}
+
+// WithLanguagePermissions returns a ContributorOption setting the languages
+// the contributor has access to. It replaces any previous permissions.
+func WithLanguagePermissions(permissions ...LanguagePermission) ContributorOption {
+	return func(v *url.Values) error {
+		for _, p := range permissions {
+			if strings.TrimSpace(p.ISO) == "" {
+				return errors.New("lokalise: language permission without language code")
+			}
+		}
+		data, err := json.Marshal(permissions)
+		if err != nil {
+			return err
+		}
+		v.Set("languages", string(data))
+		return nil
+	}
+}
+
+type contributorsResponse struct {
+	Contributors []Contributor `json:"contributors"`
+	Response     response      `json:"response"`
+}
+
+type contributorResponse struct {
+	Contributor Contributor `json:"contributor"`
+	Response    response    `json:"response"`
+}
+
+// ListContributors returns the contributors of project with ID projectID.
+func (c *Client) ListContributors(ctx context.Context, projectID string) ([]Contributor, error) {
+	form := url.Values{}
+	form.Set("id", projectID)
+	var dat contributorsResponse
+	if err := c.do(ctx, "contributor/list", c.formRequest(ctx, form), &dat); err != nil {
+		return nil, err
+	}
+	return dat.Contributors, nil
+}
+
+// InviteContributor invites the user with the given email address to project
+// with ID projectID and returns the new contributor. Non-admin contributors
+// need WithLanguagePermissions to access any language.
+func (c *Client) InviteContributor(ctx context.Context, projectID, email string, opts ...ContributorOption) (Contributor, error) {
+	if !strings.Contains(email, "@") {
+		return Contributor{}, errors.New("lokalise: invalid email address " + email)
+	}
+	form := &url.Values{}
+	form.Set("email", email)
+	return c.contributor(ctx, "contributor/add", projectID, form, opts, c.doUnlessProcessed)
+}
+
+// UpdateContributor changes the contributor with user ID userID of project
+// with ID projectID as set by opts and returns it.
+func (c *Client) UpdateContributor(ctx context.Context, projectID string, userID int64, opts ...ContributorOption) (Contributor, error) {
+	form := &url.Values{}
+	form.Set("user_id", strconv.FormatInt(userID, 10))
+	return c.contributor(ctx, "contributor/update", projectID, form, opts, c.do)
+}
+
+// RemoveContributor removes the contributor with user ID userID from project
+// with ID projectID.
+func (c *Client) RemoveContributor(ctx context.Context, projectID string, userID int64) error {
+	form := url.Values{}
+	form.Set("id", projectID)
+	form.Set("user_id", strconv.FormatInt(userID, 10))
+	return c.doUnlessProcessed(ctx, "contributor/remove", c.formRequest(ctx, form), &struct{}{})
+}
+
+// contributor sends form with opts applied to endpoint with do.
+func (c *Client) contributor(ctx context.Context, endpoint, projectID string, form *url.Values, opts []ContributorOption, do doFunc) (Contributor, error) {
+	form.Set("id", projectID)
+	for _, opt := range opts {
+		if err := opt(form); err != nil {
+			return Contributor{}, err
+		}
+	}
+	var dat contributorResponse
+	if err := do(ctx, endpoint, c.formRequest(ctx, *form), &dat); err != nil {
+		return Contributor{}, err
+	}
+	return dat.Contributor, nil
+}
@@ -0,0 +1,92 @@
+package lokalise_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+	"github.com/lokalise/lokalise-cli-go/lokalise/lokalisetest"
+)
+
+func TestContributors(t *testing.T) {
+	srv, c := newFake(t, lokalisetest.Project{
+		ID:           projectID,
+		Name:         "App",
+		Admin:        true,
+		Languages:    []string{"en", "de"},
+		Contributors: []lokalisetest.Contributor{{Email: "owner@example.com", Admin: true}},
+	})
+	defer srv.Close()
+	ctx := context.Background()
+
+	invited, err := c.InviteContributor(ctx, projectID, "translator@example.com",
+		lokalise.WithFullname("Translator"),
+		lokalise.WithLanguagePermissions(
+			lokalise.LanguagePermission{ISO: "de", Writable: true},
+			lokalise.LanguagePermission{ISO: "en"},
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if invited.ID == 0 || invited.Name != "Translator" || invited.Role() != lokalise.RoleContributor {
+		t.Errorf("invited contributor %+v", invited)
+	}
+	if len(invited.Languages) != 2 || invited.Languages[0] != (lokalise.LanguagePermission{ISO: "de", Writable: true}) {
+		t.Errorf("languages = %+v, want de writable and en read-only", invited.Languages)
+	}
+
+	updated, err := c.UpdateContributor(ctx, projectID, invited.ID, lokalise.WithAdmin(true), lokalise.WithReviewer(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !updated.Role().IsAdmin() || !updated.Reviewer || len(updated.Languages) != 2 {
+		t.Errorf("updated contributor %+v, want an admin reviewer", updated)
+	}
+
+	contributors, err := c.ListContributors(ctx, projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contributors) != 2 {
+		t.Errorf("contributors = %+v, want 2", contributors)
+	}
+
+	if err := c.RemoveContributor(ctx, projectID, invited.ID); err != nil {
+		t.Fatal(err)
+	}
+	if contributors, _ := c.ListContributors(ctx, projectID); len(contributors) != 1 || contributors[0].Email != "owner@example.com" {
+		t.Errorf("contributors = %+v, want the owner only", contributors)
+	}
+}
+
+func TestInviteContributorErrors(t *testing.T) {
+	srv, c := newFake(t, lokalisetest.Project{
+		ID:           projectID,
+		Name:         "App",
+		Admin:        true,
+		Languages:    []string{"en"},
+		Contributors: []lokalisetest.Contributor{{Email: "owner@example.com", Admin: true}},
+	})
+	defer srv.Close()
+	ctx := context.Background()
+
+	if _, err := c.InviteContributor(ctx, projectID, "owner@example.com"); err == nil {
+		t.Error("existing contributor: got no error")
+	}
+	if _, err := c.InviteContributor(ctx, projectID, "translator@example.com", lokalise.WithLanguagePermissions(lokalise.LanguagePermission{ISO: "fr"})); err == nil {
+		t.Error("unknown language: got no error")
+	}
+
+	// Invalid input is rejected before sending the request.
+	n := len(srv.Requests())
+	if _, err := c.InviteContributor(ctx, projectID, "translator"); err == nil {
+		t.Error("invalid email address: got no error")
+	}
+	if _, err := c.InviteContributor(ctx, projectID, "translator@example.com", lokalise.WithLanguagePermissions(lokalise.LanguagePermission{})); err == nil {
+		t.Error("permission without language: got no error")
+	}
+	if got := len(srv.Requests()); got != n {
+		t.Errorf("got %d requests for invalid input, want none", got-n)
+	}
+}
@@ -0,0 +1,291 @@
+package lokalise
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DownloadOption is a function setting options for a bundle download.
+type DownloadOption func(*download) error
+
+type download struct {
+	progress func(written, total int64)
+}
+
+// WithProgress returns a DownloadOption reporting the download progress to f.
+// The total is -1 if the size of the bundle is unknown.
+func WithProgress(f func(written, total int64)) DownloadOption {
+	return func(d *download) error {
+		d.progress = f
+		return nil
+	}
+}
+
+// DownloadBundle writes the export bundle b to w using the default client.
+// See Client.DownloadBundle for details.
+//
+// Unlike the other package level functions, DownloadBundle takes no API
+// token, since bundles are downloaded from their asset URL without one.
+func DownloadBundle(ctx context.Context, b Bundle, w io.Writer, opts ...DownloadOption) (int64, error) {
+	return DefaultClient.DownloadBundle(ctx, b, w, opts...)
+}
+
+// DownloadBundleFile downloads the export bundle b to path using the default
+// client. See Client.DownloadBundleFile for details.
+func DownloadBundleFile(ctx context.Context, b Bundle, path string, opts ...DownloadOption) (int64, error) {
+	return DefaultClient.DownloadBundleFile(ctx, b, path, opts...)
+}
+
+// DownloadBundle writes the export bundle b to w and returns the number of
+// bytes written.
+//
+// The HTTP status and the size of the bundle are verified. Truncated
+// transfers are resumed with ranged requests according to the RetryPolicy of
+// the client, provided the server identifies the bundle with an ETag or
+// Last-Modified header. If the bundle changes in between, an error is
+// returned since w cannot be rewound.
+//
+// Large bundles may take a while, so the timeout of the client does not
+// apply. Use ctx to limit the download instead.
+func (c *Client) DownloadBundle(ctx context.Context, b Bundle, w io.Writer, opts ...DownloadOption) (int64, error) {
+	t := transfer{w: w}
+	err := c.download(ctx, b, &t, opts)
+	return t.written, err
+}
+
+// DownloadBundleFile downloads the export bundle b to path and returns the
+// size of the bundle.
+//
+// The bundle is written to path with the suffix ".part" first and renamed
+// once complete. The ETag or Last-Modified header identifying the bundle is
+// kept in a file with the suffix ".part.validator" alongside. If both exist
+// from an interrupted download, the download resumes where it stopped,
+// unless the bundle has changed since. Otherwise it restarts from the
+// beginning.
+func (c *Client) DownloadBundleFile(ctx context.Context, b Bundle, path string, opts ...DownloadOption) (int64, error) {
+	part := path + ".part"
+	validator := part + ".validator"
+	f, err := os.OpenFile(part, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return 0, err
+	}
+	t := transfer{
+		w:       f,
+		written: info.Size(),
+		reset: func() error {
+			return f.Truncate(0)
+		},
+	}
+	if v, err := ioutil.ReadFile(validator); err == nil {
+		t.validator = string(v)
+	}
+	err = c.download(ctx, b, &t, opts)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		if t.validator == "" {
+			os.Remove(validator)
+		} else {
+			ioutil.WriteFile(validator, []byte(t.validator), 0644)
+		}
+		return t.written, err
+	}
+	os.Remove(validator)
+	return t.written, os.Rename(part, path)
+}
+
+// transfer is the state of a bundle download across attempts.
+type transfer struct {
+	w io.Writer
+	// written is the number of bytes of the bundle written to w.
+	written int64
+	// validator is the ETag or Last-Modified header of the bundle. It is
+	// sent as If-Range so that only the same bundle is resumed.
+	validator string
+	// reset truncates w to restart the download, nil if w cannot be rewound.
+	reset func() error
+}
+
+// download writes the bundle to t, resuming after the bytes t has written
+// already.
+func (c *Client) download(ctx context.Context, b Bundle, t *transfer, opts []DownloadOption) error {
+	var d download
+	for _, opt := range opts {
+		err := opt(&d)
+		if err != nil {
+			return err
+		}
+	}
+	u := b.FullFile
+	if u == "" && b.File != "" {
+		u = c.assetURL + b.File
+	}
+	if u == "" {
+		return errors.New("lokalise: bundle has no file to download")
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.downloadAttempt(ctx, u, t, &d)
+		if err == nil {
+			return nil
+		}
+		if !IsRetryable(err) || attempt >= c.retry.MaxAttempts || ctx.Err() != nil {
+			return err
+		}
+		var wait time.Duration
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) {
+			wait, _ = retryAfter(httpErr.Header)
+		}
+		if sleep(ctx, c.retry.delay(attempt, wait)) != nil {
+			return err
+		}
+	}
+}
+
+// downloadAttempt requests the rest of the bundle and copies it to t.
+func (c *Client) downloadAttempt(ctx context.Context, u string, t *transfer, d *download) error {
+	if t.written > 0 && t.validator == "" {
+		// Without a validator a stale partial bundle cannot be told apart.
+		if t.reset == nil {
+			return errors.New("lokalise: cannot resume bundle download, the server sent no ETag or Last-Modified header")
+		}
+		if err := t.reset(); err != nil {
+			return err
+		}
+		t.written = 0
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	if t.written > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", t.written))
+		req.Header.Set("If-Range", t.validator)
+	}
+	resp, err := c.send(c.fetch, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	total := int64(-1)
+	switch resp.StatusCode {
+	case http.StatusOK:
+		total = resp.ContentLength
+		validator := responseValidator(resp)
+		if t.written > 0 {
+			// The range was ignored, either because the bundle changed or
+			// because the server does not support ranges.
+			switch {
+			case t.reset != nil:
+				if err := t.reset(); err != nil {
+					return err
+				}
+				t.written = 0
+			case validator != "" && validator == t.validator:
+				if _, err := io.CopyN(ioutil.Discard, resp.Body, t.written); err != nil {
+					if err == io.EOF {
+						err = io.ErrUnexpectedEOF
+					}
+					return err
+				}
+			default:
+				return errors.New("lokalise: bundle changed while downloading")
+			}
+		}
+		t.validator = validator
+	case http.StatusPartialContent:
+		start, size, ok := contentRange(resp.Header.Get("Content-Range"))
+		if !ok || start != t.written {
+			return fmt.Errorf("lokalise: unexpected content range %q resuming at byte %d", resp.Header.Get("Content-Range"), t.written)
+		}
+		total = size
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Nothing left to download if the partial content is complete. The
+		// bundle is unchanged, otherwise If-Range yields the full bundle.
+		if _, size, ok := contentRange(resp.Header.Get("Content-Range")); ok && size == t.written {
+			return nil
+		}
+		return errorFromStatus(resp, c.apiToken)
+	default:
+		return errorFromStatus(resp, c.apiToken)
+	}
+
+	pw := &progressWriter{w: t.w, written: t.written, total: total, progress: d.progress}
+	_, err = io.Copy(pw, resp.Body)
+	t.written = pw.written
+	if err != nil {
+		return err
+	}
+	if total >= 0 && t.written != total {
+		return fmt.Errorf("lokalise: bundle download truncated at %d of %d bytes: %w", t.written, total, io.ErrUnexpectedEOF)
+	}
+	return nil
+}
+
+// responseValidator returns the strong ETag or else the Last-Modified header
+// of resp for use with If-Range, which does not allow weak ETags.
+func responseValidator(resp *http.Response) string {
+	if etag := resp.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return resp.Header.Get("Last-Modified")
+}
+
+// contentRange parses a Content-Range header of the form "bytes 0-99/100" or
+// "bytes */100". It returns false if the complete size is unknown.
+func contentRange(v string) (start, size int64, ok bool) {
+	v = strings.TrimPrefix(v, "bytes ")
+	i := strings.Index(v, "/")
+	if i < 0 {
+		return 0, 0, false
+	}
+	size, err := strconv.ParseInt(v[i+1:], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	r := v[:i]
+	if r == "*" {
+		return 0, size, true
+	}
+	j := strings.Index(r, "-")
+	if j < 0 {
+		return 0, 0, false
+	}
+	start, err = strconv.ParseInt(r[:j], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, size, true
+}
+
+type progressWriter struct {
+	w        io.Writer
+	written  int64
+	total    int64
+	progress func(written, total int64)
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.written += int64(n)
+	if w.progress != nil {
+		w.progress(w.written, w.total)
+	}
+	return n, err
+}
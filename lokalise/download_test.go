@@ -0,0 +1,287 @@
+package lokalise_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+// bundleServer serves bundles with the given ETags in turn, cutting off the
+// responses listed in truncate after half of the content. It records the
+// Range header of every request.
+type bundleServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	content  []byte
+	etags    []string
+	truncate map[int]bool
+	ranges   []string
+}
+
+func newBundleServer(content []byte, etags ...string) *bundleServer {
+	s := &bundleServer{content: content, etags: etags, truncate: map[int]bool{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *bundleServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	n := len(s.ranges)
+	s.ranges = append(s.ranges, r.Header.Get("Range"))
+	truncate := s.truncate[n]
+	s.mu.Unlock()
+
+	w.Header().Set("ETag", s.etags[n%len(s.etags)])
+	if truncate {
+		w.Header().Set("Content-Length", strconv.Itoa(len(s.content)))
+		w.Write(s.content[:len(s.content)/2])
+		return
+	}
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(s.content))
+}
+
+// requestedRanges returns the Range headers of the requests so far.
+func (s *bundleServer) requestedRanges() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.ranges...)
+}
+
+func (s *bundleServer) bundle() lokalise.Bundle {
+	return lokalise.Bundle{File: "export/App.zip", FullFile: s.URL + "/export/App.zip"}
+}
+
+var bundleContent = []byte(strings.Repeat("0123456789", 1000))
+
+func TestDownloadBundleFileResume(t *testing.T) {
+	const etag = `"v1"`
+	half := len(bundleContent) / 2
+	tests := []struct {
+		name      string
+		part      []byte
+		validator string
+		wantRange string
+	}{
+		{"resume", bundleContent[:half], etag, "bytes=" + strconv.Itoa(half) + "-"},
+		{"changed bundle", []byte("OLDOLDOLD"), `"stale"`, "bytes=9-"},
+		{"changed bundle of same size", bytes.Repeat([]byte("x"), len(bundleContent)), `"stale"`, "bytes=" + strconv.Itoa(len(bundleContent)) + "-"},
+		{"no validator", []byte("OLDOLDOLD"), "", ""},
+		{"no partial file", nil, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newBundleServer(bundleContent, etag)
+			defer srv.Close()
+			c, _ := lokalise.NewClient(fastRetries)
+
+			dir, err := ioutil.TempDir("", "lokalise")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "bundle.zip")
+			if tt.part != nil {
+				ioutil.WriteFile(path+".part", tt.part, 0644)
+			}
+			if tt.validator != "" {
+				ioutil.WriteFile(path+".part.validator", []byte(tt.validator), 0644)
+			}
+
+			n, err := c.DownloadBundleFile(context.Background(), srv.bundle(), path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, _ := ioutil.ReadFile(path)
+			if n != int64(len(bundleContent)) || !bytes.Equal(got, bundleContent) {
+				t.Errorf("downloaded %d bytes, want %d", n, len(bundleContent))
+			}
+			if ranges := srv.requestedRanges(); len(ranges) != 1 || ranges[0] != tt.wantRange {
+				t.Errorf("ranges = %q, want [%q]", ranges, tt.wantRange)
+			}
+			for _, leftover := range []string{path + ".part", path + ".part.validator"} {
+				if _, err := os.Stat(leftover); !os.IsNotExist(err) {
+					t.Errorf("%s left behind", filepath.Base(leftover))
+				}
+			}
+		})
+	}
+}
+
+func TestDownloadBundleFileInterrupted(t *testing.T) {
+	srv := newBundleServer(bundleContent, `"v1"`)
+	defer srv.Close()
+	srv.truncate[0] = true
+	dir, err := ioutil.TempDir("", "lokalise")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "bundle.zip")
+
+	// The partial file and its validator are kept for the next call.
+	c, _ := lokalise.NewClient(lokalise.WithRetryPolicy(lokalise.RetryPolicy{MaxAttempts: 1}))
+	if _, err := c.DownloadBundleFile(context.Background(), srv.bundle(), path); err == nil {
+		t.Fatal("got no error")
+	}
+	if v, _ := ioutil.ReadFile(path + ".part.validator"); string(v) != `"v1"` {
+		t.Errorf("validator = %q, want the ETag", v)
+	}
+
+	if _, err := c.DownloadBundleFile(context.Background(), srv.bundle(), path); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := ioutil.ReadFile(path); !bytes.Equal(got, bundleContent) {
+		t.Errorf("downloaded %d bytes, want %d", len(got), len(bundleContent))
+	}
+	if ranges := srv.requestedRanges(); len(ranges) != 2 || ranges[1] != "bytes=5000-" {
+		t.Errorf("ranges = %q, want the second download resumed", ranges)
+	}
+}
+
+func TestDownloadBundleTruncated(t *testing.T) {
+	t.Run("resumed", func(t *testing.T) {
+		srv := newBundleServer(bundleContent, `"v1"`)
+		defer srv.Close()
+		srv.truncate[0] = true
+		c, _ := lokalise.NewClient(fastRetries)
+
+		var buf bytes.Buffer
+		var progress int64
+		n, err := c.DownloadBundle(context.Background(), srv.bundle(), &buf, lokalise.WithProgress(func(written, total int64) {
+			progress = written
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != int64(len(bundleContent)) || !bytes.Equal(buf.Bytes(), bundleContent) || progress != n {
+			t.Errorf("downloaded %d bytes, progress %d, want %d", n, progress, len(bundleContent))
+		}
+		if ranges := srv.requestedRanges(); strings.Join(ranges, ",") != ",bytes=5000-" {
+			t.Errorf("ranges = %q, want the second request resumed", ranges)
+		}
+	})
+
+	t.Run("no retries", func(t *testing.T) {
+		srv := newBundleServer(bundleContent, `"v1"`)
+		defer srv.Close()
+		srv.truncate[0] = true
+		c, _ := lokalise.NewClient(lokalise.WithRetryPolicy(lokalise.RetryPolicy{MaxAttempts: 1}))
+
+		n, err := c.DownloadBundle(context.Background(), srv.bundle(), ioutil.Discard)
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("error = %v, want io.ErrUnexpectedEOF", err)
+		}
+		if n != int64(len(bundleContent)/2) {
+			t.Errorf("downloaded %d bytes, want %d", n, len(bundleContent)/2)
+		}
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		srv := newBundleServer(bundleContent, `"v1"`, `"v2"`)
+		defer srv.Close()
+		srv.truncate[0] = true
+		c, _ := lokalise.NewClient(fastRetries)
+
+		if _, err := c.DownloadBundle(context.Background(), srv.bundle(), ioutil.Discard); err == nil {
+			t.Error("got no error, want the changed bundle to be rejected")
+		}
+	})
+}
+
+func TestDownloadBundleDefaultClient(t *testing.T) {
+	srv := newBundleServer(bundleContent, `"v1"`)
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	n, err := lokalise.DownloadBundle(context.Background(), srv.bundle(), &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(bundleContent)) || !bytes.Equal(buf.Bytes(), bundleContent) {
+		t.Errorf("downloaded %d bytes, want %d", n, len(bundleContent))
+	}
+}
+
+func TestDownloadBundleStatus(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	c, _ := lokalise.NewClient(fastRetries)
+
+	_, err := c.DownloadBundle(context.Background(), lokalise.Bundle{FullFile: srv.URL}, ioutil.Discard)
+	var httpErr *lokalise.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound {
+		t.Errorf("error = %v, want an HTTPError with status 404", err)
+	}
+}
+
+func TestDownloadBundleRetryAfter(t *testing.T) {
+	srv := newScripted(
+		reply{status: http.StatusServiceUnavailable, retryAfter: "1"},
+		reply{body: string(bundleContent)},
+	)
+	defer srv.Close()
+	// The backoff alone would exceed the test timeout.
+	c, _ := lokalise.NewClient(lokalise.WithRetryPolicy(lokalise.RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Hour,
+		MaxBackoff:  time.Hour,
+	}))
+
+	start := time.Now()
+	var buf bytes.Buffer
+	if _, err := c.DownloadBundle(context.Background(), lokalise.Bundle{FullFile: srv.URL}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d < time.Second || d > time.Minute {
+		t.Errorf("took %v, want the delay of Retry-After", d)
+	}
+	if !bytes.Equal(buf.Bytes(), bundleContent) {
+		t.Errorf("downloaded %d bytes, want %d", buf.Len(), len(bundleContent))
+	}
+}
+
+func TestDownloadBundleNoTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(bundleContent)))
+		w.Write(bundleContent[:len(bundleContent)/2])
+		w.(http.Flusher).Flush()
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		w.Write(bundleContent[len(bundleContent)/2:])
+	}))
+	defer srv.Close()
+	// The timeout applies to API requests only.
+	c, _ := lokalise.NewClient(lokalise.WithTimeout(50*time.Millisecond), lokalise.WithRetryPolicy(lokalise.RetryPolicy{MaxAttempts: 1}))
+	b := lokalise.Bundle{FullFile: srv.URL}
+
+	var buf bytes.Buffer
+	if _, err := c.DownloadBundle(context.Background(), b, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), bundleContent) {
+		t.Errorf("downloaded %d bytes, want %d", buf.Len(), len(bundleContent))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.DownloadBundle(ctx, b, ioutil.Discard); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+}
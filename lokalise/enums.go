@@ -0,0 +1,226 @@
+package lokalise
+
+// Filter limits the range of keys in an export, see WithFilter.
+type Filter string
+
+// Filters supported by the API.
+const (
+	FilterTranslated       Filter = "translated"
+	FilterNonfuzzy         Filter = "nonfuzzy"
+	FilterNonhidden        Filter = "nonhidden"
+	FilterReviewed         Filter = "reviewed"
+	FilterProofread        Filter = "proofread"
+	FilterLastReviewedOnly Filter = "last_reviewed_only"
+)
+
+// SortOrder is the order of keys in an export, see WithSortOrder.
+type SortOrder string
+
+// Sort orders supported by the API.
+const (
+	SortFirstAdded  SortOrder = "first_added"
+	SortLastAdded   SortOrder = "last_added"
+	SortLastUpdated SortOrder = "last_updated"
+	SortAZ          SortOrder = "a_z"
+	SortZA          SortOrder = "z_a"
+)
+
+// PluralFormat is the format of plural forms in an export, see
+// WithPluralFormat.
+type PluralFormat string
+
+// Plural formats supported by the API.
+const (
+	PluralJSONString PluralFormat = "json_string"
+	PluralICU        PluralFormat = "icu"
+	PluralArray      PluralFormat = "array"
+	PluralGeneric    PluralFormat = "generic"
+	PluralSymfony    PluralFormat = "symfony"
+	PluralRaw        PluralFormat = "raw"
+	PluralI18next    PluralFormat = "i18next"
+)
+
+// PlaceholderFormat is the format of placeholders in an export, see
+// WithPlaceholderFormat.
+type PlaceholderFormat string
+
+// Placeholder formats supported by the API.
+const (
+	PlaceholderPrintf  PlaceholderFormat = "printf"
+	PlaceholderIOS     PlaceholderFormat = "ios"
+	PlaceholderICU     PlaceholderFormat = "icu"
+	PlaceholderNet     PlaceholderFormat = "net"
+	PlaceholderSymfony PlaceholderFormat = "symfony"
+	PlaceholderRaw     PlaceholderFormat = "raw"
+	PlaceholderI18n    PlaceholderFormat = "i18n"
+)
+
+// Indentation is the indentation of exported files, see WithIndentation.
+type Indentation string
+
+// Indentations supported by the API.
+const (
+	Indent1Space  Indentation = "1sp"
+	Indent2Spaces Indentation = "2sp"
+	Indent3Spaces Indentation = "3sp"
+	Indent4Spaces Indentation = "4sp"
+	Indent5Spaces Indentation = "5sp"
+	Indent6Spaces Indentation = "6sp"
+	Indent7Spaces Indentation = "7sp"
+	Indent8Spaces Indentation = "8sp"
+	IndentTab     Indentation = "tab"
+)
+
+// Trigger is an integration to trigger with an export, see WithTriggers.
+type Trigger string
+
+// Triggers supported by the API.
+const (
+	TriggerAmazonS3  Trigger = "amazons3"
+	TriggerGCS       Trigger = "gcs"
+	TriggerGitHub    Trigger = "github"
+	TriggerGitLab    Trigger = "gitlab"
+	TriggerBitbucket Trigger = "bitbucket"
+)
+
+// EmptyMode is how empty translations are exported, see WithEmpty.
+type EmptyMode string
+
+// Empty modes supported by the API.
+const (
+	EmptyAsEmpty EmptyMode = "empty"
+	EmptyAsBase  EmptyMode = "base"
+	EmptySkip    EmptyMode = "skip"
+)
+
+// JavaPropertiesEncoding is the encoding of exported .properties files, see
+// WithJavaPropertiesEncoding.
+type JavaPropertiesEncoding string
+
+// Encodings of .properties files supported by the API.
+const (
+	PropertiesUTF8   JavaPropertiesEncoding = "utf-8"
+	PropertiesLatin1 JavaPropertiesEncoding = "latin-1"
+)
+
+// JavaPropertiesSeparator separates keys and values in exported .properties
+// files, see WithJavaPropertiesSeparator.
+type JavaPropertiesSeparator string
+
+// Separators of .properties files supported by the API.
+const (
+	PropertiesEquals JavaPropertiesSeparator = "="
+	PropertiesColon  JavaPropertiesSeparator = ":"
+)
+
+// Platform is a platform keys are assigned to.
+type Platform string
+
+// Platforms supported by the API.
+const (
+	PlatformIOS     Platform = "ios"
+	PlatformAndroid Platform = "android"
+	PlatformWeb     Platform = "web"
+	PlatformOther   Platform = "other"
+)
+
+// FileType is a file format supported for exports.
+type FileType struct {
+	// Name is the value of the export type argument, e.g. "json".
+	Name string
+	// Description names the format, e.g. "iOS strings".
+	Description string
+	// Extension is the file extension of exported files without the dot, which
+	// the %FORMAT% placeholder of a bundle structure renders to.
+	Extension string
+}
+
+// fileTypes is the registry of export file types. See
+// https://lokalise.co/apidocs#file_formats for details on each format.
+var fileTypes = []FileType{
+	{"strings", "iOS strings", "strings"},
+	{"stringsdict", "iOS plural strings", "stringsdict"},
+	{"plist", "iOS/macOS property list", "plist"},
+	{"xliff", "XLIFF", "xliff"},
+	{"xml", "Android resources", "xml"},
+	{"json", "JSON", "json"},
+	{"yaml", "YAML", "yml"},
+	{"po", "Gettext PO", "po"},
+	{"properties", "Java properties", "properties"},
+	{"php", "PHP array", "php"},
+	{"ini", "INI", "ini"},
+	{"resx", ".NET resources", "resx"},
+	{"ts", "Qt translation source", "ts"},
+	{"arb", "Flutter ARB", "arb"},
+	{"csv", "CSV", "csv"},
+	{"xlsx", "Excel spreadsheet", "xlsx"},
+}
+
+// FileTypes returns the registry of supported export file types.
+func FileTypes() []FileType {
+	return append([]FileType(nil), fileTypes...)
+}
+
+// LookupFileType returns the export file type with the given name.
+func LookupFileType(name string) (FileType, bool) {
+	for _, ft := range fileTypes {
+		if ft.Name == name {
+			return ft, true
+		}
+	}
+	return FileType{}, false
+}
+
+// allowedValues is the source of truth for option values restricted by the
+// API, keyed by API parameter name. Option validators and the CLI help text
+// are derived from it.
+var allowedValues = map[string][]string{
+	"filter": {
+		string(FilterTranslated), string(FilterNonfuzzy), string(FilterNonhidden),
+		string(FilterReviewed), string(FilterProofread), string(FilterLastReviewedOnly),
+	},
+	"export_sort": {
+		string(SortFirstAdded), string(SortLastAdded), string(SortLastUpdated), string(SortAZ), string(SortZA),
+	},
+	"plural_format": {
+		string(PluralJSONString), string(PluralICU), string(PluralArray), string(PluralGeneric),
+		string(PluralSymfony), string(PluralRaw), string(PluralI18next),
+	},
+	"placeholder_format": {
+		string(PlaceholderPrintf), string(PlaceholderIOS), string(PlaceholderICU), string(PlaceholderNet),
+		string(PlaceholderSymfony), string(PlaceholderRaw), string(PlaceholderI18n),
+	},
+	"indentation": {
+		string(Indent1Space), string(Indent2Spaces), string(Indent3Spaces), string(Indent4Spaces),
+		string(Indent5Spaces), string(Indent6Spaces), string(Indent7Spaces), string(Indent8Spaces), string(IndentTab),
+	},
+	"triggers": {
+		string(TriggerAmazonS3), string(TriggerGCS), string(TriggerGitHub), string(TriggerGitLab), string(TriggerBitbucket),
+	},
+	"platforms": {
+		string(PlatformIOS), string(PlatformAndroid), string(PlatformWeb), string(PlatformOther),
+	},
+	"export_empty": {
+		string(EmptyAsEmpty), string(EmptyAsBase), string(EmptySkip),
+	},
+	"java_properties_encoding": {
+		string(PropertiesUTF8), string(PropertiesLatin1),
+	},
+	"java_properties_separator": {
+		string(PropertiesEquals), string(PropertiesColon),
+	},
+}
+
+// AllowedValues returns the values allowed for the option with the given API
+// parameter name, e.g. "export_sort", or nil if the option is not
+// restricted. For "type" the names of the registered FileTypes are returned.
+func AllowedValues(option string) []string {
+	if option == "type" {
+		names := make([]string, len(fileTypes))
+		for i, ft := range fileTypes {
+			names[i] = ft.Name
+		}
+		return names
+	}
+	return append([]string(nil), allowedValues[option]...)
+}
@@ -0,0 +1,130 @@
+package lokalise_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+func TestEnumWireValues(t *testing.T) {
+	tests := []struct {
+		opt   lokalise.ExportOption
+		field string
+		want  string
+	}{
+		{lokalise.WithFilter(lokalise.FilterTranslated), "filter", `["translated"]`},
+		{lokalise.WithFilter(lokalise.FilterNonfuzzy), "filter", `["nonfuzzy"]`},
+		{lokalise.WithFilter(lokalise.FilterNonhidden), "filter", `["nonhidden"]`},
+		{lokalise.WithFilter(lokalise.FilterReviewed), "filter", `["reviewed"]`},
+		{lokalise.WithFilter(lokalise.FilterProofread), "filter", `["proofread"]`},
+		{lokalise.WithFilter(lokalise.FilterLastReviewedOnly), "filter", `["last_reviewed_only"]`},
+		{lokalise.WithSortOrder(lokalise.SortFirstAdded), "export_sort", "first_added"},
+		{lokalise.WithSortOrder(lokalise.SortLastAdded), "export_sort", "last_added"},
+		{lokalise.WithSortOrder(lokalise.SortLastUpdated), "export_sort", "last_updated"},
+		{lokalise.WithSortOrder(lokalise.SortAZ), "export_sort", "a_z"},
+		{lokalise.WithSortOrder(lokalise.SortZA), "export_sort", "z_a"},
+		{lokalise.WithEmpty(lokalise.EmptyAsEmpty), "export_empty", "empty"},
+		{lokalise.WithEmpty(lokalise.EmptyAsBase), "export_empty", "base"},
+		{lokalise.WithEmpty(lokalise.EmptySkip), "export_empty", "skip"},
+		{lokalise.WithJavaPropertiesEncoding(lokalise.PropertiesUTF8), "java_properties_encoding", "utf-8"},
+		{lokalise.WithJavaPropertiesEncoding(lokalise.PropertiesLatin1), "java_properties_encoding", "latin-1"},
+		{lokalise.WithJavaPropertiesSeparator(lokalise.PropertiesEquals), "java_properties_separator", "="},
+		{lokalise.WithJavaPropertiesSeparator(lokalise.PropertiesColon), "java_properties_separator", ":"},
+		{lokalise.WithPluralFormat(lokalise.PluralJSONString), "plural_format", "json_string"},
+		{lokalise.WithPluralFormat(lokalise.PluralICU), "plural_format", "icu"},
+		{lokalise.WithPluralFormat(lokalise.PluralArray), "plural_format", "array"},
+		{lokalise.WithPluralFormat(lokalise.PluralGeneric), "plural_format", "generic"},
+		{lokalise.WithPluralFormat(lokalise.PluralSymfony), "plural_format", "symfony"},
+		{lokalise.WithPluralFormat(lokalise.PluralRaw), "plural_format", "raw"},
+		{lokalise.WithPluralFormat(lokalise.PluralI18next), "plural_format", "i18next"},
+		{lokalise.WithPlaceholderFormat(lokalise.PlaceholderPrintf), "placeholder_format", "printf"},
+		{lokalise.WithPlaceholderFormat(lokalise.PlaceholderIOS), "placeholder_format", "ios"},
+		{lokalise.WithPlaceholderFormat(lokalise.PlaceholderICU), "placeholder_format", "icu"},
+		{lokalise.WithPlaceholderFormat(lokalise.PlaceholderNet), "placeholder_format", "net"},
+		{lokalise.WithPlaceholderFormat(lokalise.PlaceholderSymfony), "placeholder_format", "symfony"},
+		{lokalise.WithPlaceholderFormat(lokalise.PlaceholderRaw), "placeholder_format", "raw"},
+		{lokalise.WithPlaceholderFormat(lokalise.PlaceholderI18n), "placeholder_format", "i18n"},
+		{lokalise.WithIndentation(lokalise.Indent1Space), "indentation", "1sp"},
+		{lokalise.WithIndentation(lokalise.Indent2Spaces), "indentation", "2sp"},
+		{lokalise.WithIndentation(lokalise.Indent3Spaces), "indentation", "3sp"},
+		{lokalise.WithIndentation(lokalise.Indent4Spaces), "indentation", "4sp"},
+		{lokalise.WithIndentation(lokalise.Indent5Spaces), "indentation", "5sp"},
+		{lokalise.WithIndentation(lokalise.Indent6Spaces), "indentation", "6sp"},
+		{lokalise.WithIndentation(lokalise.Indent7Spaces), "indentation", "7sp"},
+		{lokalise.WithIndentation(lokalise.Indent8Spaces), "indentation", "8sp"},
+		{lokalise.WithIndentation(lokalise.IndentTab), "indentation", "tab"},
+		{lokalise.WithTriggers(lokalise.TriggerAmazonS3), "triggers", `["amazons3"]`},
+		{lokalise.WithTriggers(lokalise.TriggerGCS), "triggers", `["gcs"]`},
+		{lokalise.WithTriggers(lokalise.TriggerGitHub), "triggers", `["github"]`},
+		{lokalise.WithTriggers(lokalise.TriggerGitLab), "triggers", `["gitlab"]`},
+		{lokalise.WithTriggers(lokalise.TriggerBitbucket), "triggers", `["bitbucket"]`},
+	}
+	for _, tt := range tests {
+		form := url.Values{}
+		if err := tt.opt(&form); err != nil {
+			t.Errorf("%s %s: %v", tt.field, tt.want, err)
+			continue
+		}
+		if got := form[tt.field]; len(got) != 1 || got[0] != tt.want {
+			t.Errorf("%s = %q, want %q", tt.field, got, tt.want)
+		}
+	}
+}
+
+func TestPlatformWireValues(t *testing.T) {
+	key := lokalise.Key{Platforms: []lokalise.Platform{
+		lokalise.PlatformIOS, lokalise.PlatformAndroid, lokalise.PlatformWeb, lokalise.PlatformOther,
+	}}
+	b, err := json.Marshal(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"platforms":["ios","android","web","other"]`; !strings.Contains(string(b), want) {
+		t.Errorf("key encoded as %s, want %s", b, want)
+	}
+}
+
+func TestEnumUnknownValues(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  lokalise.ExportOption
+	}{
+		{"filter", lokalise.WithFilter(lokalise.FilterReviewed, "fuzzy")},
+		{"sort order", lokalise.WithSortOrder("random")},
+		{"sort order case", lokalise.WithSortOrder("A_Z")},
+		{"plural format", lokalise.WithPluralFormat("gettext")},
+		{"placeholder format", lokalise.WithPlaceholderFormat("python")},
+		{"indentation", lokalise.WithIndentation("9sp")},
+		{"trigger", lokalise.WithTriggers("svn")},
+		{"empty", lokalise.WithEmpty("keep")},
+		{"java properties encoding", lokalise.WithJavaPropertiesEncoding("utf-16")},
+		{"java properties separator", lokalise.WithJavaPropertiesSeparator(";")},
+		{"empty sort order", lokalise.WithSortOrder("")},
+	}
+	for _, tt := range tests {
+		form := url.Values{}
+		err := tt.opt(&form)
+		if err == nil || !strings.Contains(err.Error(), "not allowed") {
+			t.Errorf("%s: error = %v, want the value rejected", tt.name, err)
+		}
+		if len(form) != 0 {
+			t.Errorf("%s: set %v, want nothing", tt.name, form)
+		}
+	}
+
+	if _, ok := lokalise.LookupFileType("docx"); ok {
+		t.Error("LookupFileType(docx) found a file type")
+	}
+	if ft, ok := lokalise.LookupFileType("yaml"); !ok || ft.Extension != "yml" {
+		t.Errorf("LookupFileType(yaml) = %+v, %v, want extension yml", ft, ok)
+	}
+	if got := lokalise.AllowedValues("langs"); got != nil {
+		t.Errorf("AllowedValues(langs) = %q, want nil for an unrestricted option", got)
+	}
+	if got := lokalise.AllowedValues("export_sort"); strings.Join(got, ",") != "first_added,last_added,last_updated,a_z,z_a" {
+		t.Errorf("AllowedValues(export_sort) = %q", got)
+	}
+}
@@ -1,8 +1,16 @@
 package lokalise
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
 )
 
 // Code is an API request status code.
@@ -43,6 +51,30 @@ const (
 	LanguageExist Code = "4050"
 )
 
+// Sentinel errors for each API request status code. Use errors.Is to test
+// whether an error returned by the package carries a given code:
+//
+//  if errors.Is(err, lokalise.ErrRateLimit) {
+//    // back off
+//  }
+var (
+	ErrMissingAPIToken         = &Error{Code: MissingAPIToken, Message: "missing API token"}
+	ErrInvalidAPIToken         = &Error{Code: InvalidAPIToken, Message: "invalid API token"}
+	ErrNoData                  = &Error{Code: NoData, Message: "no data"}
+	ErrAccessDenied            = &Error{Code: AccessDenied, Message: "access denied"}
+	ErrInvalidCall             = &Error{Code: InvalidCall, Message: "invalid call"}
+	ErrCustom                  = &Error{Code: Custom, Message: "custom error"}
+	ErrNotJSON                 = &Error{Code: NotJSON, Message: "not JSON"}
+	ErrWrongLanguageCode       = &Error{Code: WrongLanguageCode, Message: "wrong language code"}
+	ErrLanguageNotAvailable    = &Error{Code: LanguageNotAvailable, Message: "language not available"}
+	ErrLanguageNotSpecified    = &Error{Code: LanguageNotSpecified, Message: "language not specified"}
+	ErrInvalidFile             = &Error{Code: InvalidFile, Message: "invalid file"}
+	ErrInvalidExportType       = &Error{Code: InvalidExportType, Message: "invalid export type"}
+	ErrRateLimit               = &Error{Code: RateLimit, Message: "rate limit exceeded"}
+	ErrMissingRequestParameter = &Error{Code: MissingRequestParameter, Message: "missing request parameter"}
+	ErrLanguageExist           = &Error{Code: LanguageExist, Message: "language already exists"}
+)
+
 // Error represents an API request error. When the API is not able to complete a request
 // an error code and possibly a message is returned indicating why the request failed.
 type Error struct {
@@ -55,28 +87,151 @@ func (err *Error) Error() string {
 	return fmt.Sprintf("lokalise: %s %s", err.Code, err.Message)
 }
 
+// Is reports whether target is an *Error with the same Code, which makes
+// errors.Is match the sentinel errors regardless of the message.
+func (err *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == err.Code
+}
+
 // Assert that Error implements the error interface.
 var _ error = &Error{}
 
-func errorFromResponse(resp response) error {
+// maxErrorBody is the number of response body bytes kept in an HTTPError.
+const maxErrorBody = 512
+
+// HTTPError represents an API response with an unexpected HTTP status.
+//
+// HTTPError wraps an Error with code Custom, so errors.Is(err, ErrCustom)
+// holds for it.
+type HTTPError struct {
+	// StatusCode is the HTTP status code of the response, e.g. 502.
+	StatusCode int
+	// Status is the HTTP status line of the response, e.g. "502 Bad Gateway".
+	Status string
+	// Header holds the response headers.
+	Header http.Header
+	// Body holds up to the first 512 bytes of the response body.
+	Body []byte
+	// Method is the HTTP method of the request.
+	Method string
+	// URL is the request URL without its query string.
+	URL string
+}
+
+// Error implements the error interface.
+func (err *HTTPError) Error() string {
+	msg := fmt.Sprintf("lokalise: %s %s: unexpected status %s", err.Method, err.URL, err.Status)
+	if body := strings.TrimSpace(string(err.Body)); body != "" {
+		msg += ": " + body
+	}
+	return msg
+}
+
+// Unwrap returns the Error with code Custom that HTTPError replaces.
+func (err *HTTPError) Unwrap() error {
+	return &Error{
+		Code:    Custom,
+		Message: fmt.Sprintf("api request did not respond with status 200. Got %s", err.Status),
+	}
+}
+
+// Assert that HTTPError implements the error interface.
+var _ error = &HTTPError{}
+
+// IsRetryable reports whether err is a transient failure for which repeating
+// the request may succeed: a RateLimit error, a 5xx or 429 HTTP status, or a
+// network error such as a timeout, a refused or reset connection or a
+// truncated response. Cancelled and expired contexts are not retryable, nor
+// are permanent transport failures like an unsupported URL scheme or an
+// invalid certificate.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, ErrRateLimit) {
+		return true
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError || httpErr.StatusCode == http.StatusTooManyRequests
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		// url.Error implements net.Error itself, so judge its cause.
+		err = urlErr.Err
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// isRejected reports whether err shows that the API did not process the
+// request: a RateLimit error, a 429 HTTP status, or a failure to connect
+// before anything was sent.
+func isRejected(err error) bool {
+	if errors.Is(err, ErrRateLimit) {
+		return true
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// IsAuth reports whether err is caused by a missing or invalid API token or
+// by missing permissions for the requested resource.
+func IsAuth(err error) bool {
+	if errors.Is(err, ErrMissingAPIToken) || errors.Is(err, ErrInvalidAPIToken) || errors.Is(err, ErrAccessDenied) {
+		return true
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusUnauthorized || httpErr.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
+// errorFromResponse returns the Error reported by resp, if any, with every
+// occurrence of secret removed from its message.
+func errorFromResponse(resp response, secret string) error {
 	if resp.Status != "error" {
 		return nil
 	}
 	return &Error{
 		Code:    resp.Code,
-		Message: resp.Message,
+		Message: redact(resp.Message, secret),
 	}
 }
 
-func errorFromStatus(resp *http.Response) error {
+// errorFromStatus returns an HTTPError if resp has a status other than 200.
+// Every occurrence of secret is removed from the body and headers kept in the
+// error, since the API may echo the request.
+func errorFromStatus(resp *http.Response, secret string) error {
 	if resp == nil {
 		return nil
 	}
 	if resp.StatusCode == http.StatusOK {
 		return nil
 	}
-	return &Error{
-		Code:    Custom,
-		Message: fmt.Sprintf("api request did not respond with status 200. Got %s", resp.Status),
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	err := &HTTPError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Header:     redactHeader(resp.Header, secret),
+		Body:       []byte(redact(string(body), secret)),
+	}
+	if req := resp.Request; req != nil {
+		u := *req.URL
+		u.RawQuery = ""
+		err.Method = req.Method
+		err.URL = u.String()
 	}
+	return err
+}
+
+func decodeError(endpoint string, err error) error {
+	return fmt.Errorf("lokalise: decoding %s response: %w", endpoint, err)
 }
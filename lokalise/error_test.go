@@ -0,0 +1,104 @@
+package lokalise_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+func TestErrorIs(t *testing.T) {
+	err := fmt.Errorf("listing projects: %w", &lokalise.Error{Code: lokalise.AccessDenied, Message: "Access to project denied"})
+	if !errors.Is(err, lokalise.ErrAccessDenied) {
+		t.Errorf("errors.Is(%v, ErrAccessDenied) = false, want true", err)
+	}
+	if errors.Is(err, lokalise.ErrInvalidAPIToken) {
+		t.Errorf("errors.Is(%v, ErrInvalidAPIToken) = true, want false", err)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"rate limit", &lokalise.Error{Code: lokalise.RateLimit}, true},
+		{"wrapped rate limit", fmt.Errorf("importing: %w", lokalise.ErrRateLimit), true},
+		{"bad gateway", &lokalise.HTTPError{StatusCode: http.StatusBadGateway}, true},
+		{"too many requests", &lokalise.HTTPError{StatusCode: http.StatusTooManyRequests}, true},
+		{"truncated response", io.ErrUnexpectedEOF, true},
+		{"refused connection", &url.Error{Op: "Post", URL: "https://api.lokalise.co/api/", Err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}}, true},
+		{"reset connection", &url.Error{Op: "Post", URL: "https://api.lokalise.co/api/", Err: syscall.ECONNRESET}, true},
+		{"timeout", &url.Error{Op: "Post", URL: "https://api.lokalise.co/api/", Err: timeoutError{}}, true},
+		{"unsupported scheme", &url.Error{Op: "Post", URL: "htp://api.lokalise.co/api/", Err: errors.New(`unsupported protocol scheme "htp"`)}, false},
+		{"not found", &lokalise.HTTPError{StatusCode: http.StatusNotFound}, false},
+		{"access denied", &lokalise.Error{Code: lokalise.AccessDenied}, false},
+		{"canceled", context.Canceled, false},
+		{"deadline exceeded", fmt.Errorf("listing: %w", context.DeadlineExceeded), false},
+		{"other", errors.New("boom"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		if got := lokalise.IsRetryable(tt.err); got != tt.want {
+			t.Errorf("IsRetryable(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsAuth(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"invalid token", lokalise.ErrInvalidAPIToken, true},
+		{"missing token", &lokalise.Error{Code: lokalise.MissingAPIToken}, true},
+		{"access denied", &lokalise.Error{Code: lokalise.AccessDenied}, true},
+		{"unauthorized", &lokalise.HTTPError{StatusCode: http.StatusUnauthorized}, true},
+		{"forbidden", &lokalise.HTTPError{StatusCode: http.StatusForbidden}, true},
+		{"rate limit", lokalise.ErrRateLimit, false},
+		{"bad gateway", &lokalise.HTTPError{StatusCode: http.StatusBadGateway}, false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		if got := lokalise.IsAuth(tt.err); got != tt.want {
+			t.Errorf("IsAuth(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHTTPError(t *testing.T) {
+	srv := newScripted(reply{status: http.StatusBadGateway, body: "upstream unavailable"})
+	defer srv.Close()
+	c := newTestClient(t, srv.Server, lokalise.WithRetryPolicy(lokalise.RetryPolicy{MaxAttempts: 1}))
+
+	_, err := c.List(context.Background())
+	var httpErr *lokalise.HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("error = %v, want an HTTPError", err)
+	}
+	if httpErr.StatusCode != http.StatusBadGateway || string(httpErr.Body) != "upstream unavailable" {
+		t.Errorf("got status %d and body %q, want 502 and the response body", httpErr.StatusCode, httpErr.Body)
+	}
+	if httpErr.Method == "" || !strings.HasPrefix(httpErr.URL, srv.URL) || strings.Contains(httpErr.URL, "?") {
+		t.Errorf("got request %s %s, want the URL without query", httpErr.Method, httpErr.URL)
+	}
+	if !errors.Is(err, lokalise.ErrCustom) {
+		t.Errorf("errors.Is(%v, ErrCustom) = false, want true", err)
+	}
+}
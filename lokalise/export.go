@@ -1,15 +1,13 @@
 package lokalise
 
 import (
-	"encoding/json"
-	"io/ioutil"
-	"net/http"
+	"context"
 	"net/url"
-	"strings"
 )
 
 // Bundle represents file locations for a project export bundle. If a webhook URL was
-// specified in the ExportOptions the FullFile field is not set.
+// specified in the ExportOptions the API does not return FullFile, so Export derives
+// it from File and the asset URL of the client.
 type Bundle struct {
 	File     string `json:"file"`
 	FullFile string `json:"full_file"`
@@ -25,12 +23,24 @@ type exportResponse struct {
 //
 // Customize the import by setting any ExportOptions.
 //
-// If option WithWebhookURL() is set the FullFile field is not set on the bundle.
-//
-// In case of API request errors an error of type Error is returned.
+// If option WithWebhookURL() is set the API returns only the File field of the
+// bundle. FullFile is then set to File below the asset URL, where the bundle can
+// be downloaded once the webhook is called.
 func Export(apiToken, projectID, fileType string, opts ...ExportOption) (Bundle, error) {
+	return ExportContext(context.Background(), apiToken, projectID, fileType, opts...)
+}
+
+// ExportContext is like Export but carries a context for cancellation and deadlines.
+func ExportContext(ctx context.Context, apiToken, projectID, fileType string, opts ...ExportOption) (Bundle, error) {
+	return DefaultClient.withToken(apiToken).Export(ctx, projectID, fileType, opts...)
+}
+
+// Export initiates an export of project with ID projectID in file type fileType and returns the
+// file locations for the export bundle.
+//
+// See the package level Export for details.
+func (c *Client) Export(ctx context.Context, projectID, fileType string, opts ...ExportOption) (Bundle, error) {
 	form := &url.Values{}
-	form.Add("api_token", apiToken)
 	form.Add("id", projectID)
 	form.Add("type", fileType)
 	for _, opt := range opts {
@@ -40,32 +50,12 @@ func Export(apiToken, projectID, fileType string, opts ...ExportOption) (Bundle,
 		}
 	}
 
-	req, err := http.NewRequest("POST", api("project/export"), strings.NewReader(form.Encode()))
-	if err != nil {
-		return Bundle{}, err
-	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	resp, err := callAPI(req)
-	if err != nil {
-		return Bundle{}, err
-	}
-	if err := errorFromStatus(resp); err != nil {
-		return Bundle{}, err
-	}
-	body, err := ioutil.ReadAll(resp.Body)
-	defer resp.Body.Close()
-	if err != nil {
-		return Bundle{}, err
-	}
 	var dat exportResponse
-	if err := json.Unmarshal(body, &dat); err != nil {
-		return Bundle{}, err
-	}
-	if err := errorFromResponse(dat.Response); err != nil {
+	if err := c.do(ctx, "project/export", c.formRequest(ctx, *form), &dat); err != nil {
 		return Bundle{}, err
 	}
 	if len(form.Get("webhook_url")) != 0 {
-		dat.Bundle.FullFile = assetURL + dat.Bundle.File
+		dat.Bundle.FullFile = c.assetURL + dat.Bundle.File
 	}
 	return dat.Bundle, nil
 }
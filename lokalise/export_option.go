@@ -23,9 +23,13 @@ func WithOriginal(enabled bool) ExportOption {
 }
 
 // WithFilter returns an ExportOption setting a filter on the export data
-// range. Allows values are 'translated', 'nonfuzzy' and 'nonhidden', 'reviewed' and 'last_reviewed_only'.
-func WithFilter(values ...string) ExportOption {
-	return stringArrayField("filter", values, allowedSliceStrings("translated", "nonfuzzy", "nonhidden", "reviewed", "proofread", "last_reviewed_only"))
+// range. See the Filter constants for allowed values.
+func WithFilter(values ...Filter) ExportOption {
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = string(value)
+	}
+	return stringArrayField("filter", strs, allowedSliceStrings(allowedValues["filter"]...))
 }
 
 // WithBundleStructure returns an ExportOption setting the bundle structure.
@@ -36,7 +40,8 @@ func WithFilter(values ...string) ExportOption {
 // Example:
 //   locale/%LANG_ISO%.%FORMAT%
 //
-// Option is ignored if WithOriginal(true) is set.
+// Option is ignored if WithOriginal(true) is set. Use PreviewBundle to see the
+// resulting paths.
 func WithBundleStructure(structure string) ExportOption {
 	return stringField("bundle_structure", structure)
 }
@@ -84,9 +89,9 @@ func WithAll(enabled bool) ExportOption {
 }
 
 // WithEmpty returns an ExportOption setting empty string export preferences.
-// Allowed values are "empty", "base" and "skip".
-func WithEmpty(value string) ExportOption {
-	return stringField("export_empty", value, allowedStrings("empty", "base", "skip"))
+// See the EmptyMode constants for allowed values.
+func WithEmpty(mode EmptyMode) ExportOption {
+	return stringField("export_empty", string(mode), allowedStrings(allowedValues["export_empty"]...))
 }
 
 // WithComments returns an ExportOption setting whether to include key
@@ -120,24 +125,21 @@ func WithExcludeTags(tags ...string) ExportOption {
 }
 
 // WithSortOrder returns an ExportOption setting the sort order of exported keys.
-//
-// Allowed values are "first_added", "last_added", "last_updated", "a_z", "z_a".
-func WithSortOrder(order string) ExportOption {
-	return stringField("export_sort", order, allowedStrings("first_added", "last_added", "last_updated", "a_z", "z_a"))
+// See the SortOrder constants for allowed values.
+func WithSortOrder(order SortOrder) ExportOption {
+	return stringField("export_sort", string(order), allowedStrings(allowedValues["export_sort"]...))
 }
 
 // WithJavaPropertiesSeparator returns an ExportOption setting of the separator for .properties files export.
-//
-// Allowed values are ":", "=".
-func WithJavaPropertiesSeparator(order string) ExportOption {
-	return stringField("java_properties_separator", order, allowedStrings(":", "="))
+// See the JavaPropertiesSeparator constants for allowed values.
+func WithJavaPropertiesSeparator(separator JavaPropertiesSeparator) ExportOption {
+	return stringField("java_properties_separator", string(separator), allowedStrings(allowedValues["java_properties_separator"]...))
 }
 
 // WithJavaPropertiesEncoding returns an ExportOption setting of the encoding for .properties files export.
-//
-// Allowed values are "utf-8", "latin-1".
-func WithJavaPropertiesEncoding(order string) ExportOption {
-	return stringField("java_properties_encoding", order, allowedStrings("utf-8", "latin-1"))
+// See the JavaPropertiesEncoding constants for allowed values.
+func WithJavaPropertiesEncoding(encoding JavaPropertiesEncoding) ExportOption {
+	return stringField("java_properties_encoding", string(encoding), allowedStrings(allowedValues["java_properties_encoding"]...))
 }
 
 // WithExportReplaceBreaks returns an ExportOption setting whether to replace '\n' with
@@ -174,9 +176,13 @@ func WithNoLanguageFolders(enabled bool) ExportOption {
 //
 // Ensure this feature is enabled in project settings before use.
 //
-// Allowed values are "amazon", "gcs" and "github".
-func WithTriggers(triggers ...string) ExportOption {
-	return stringArrayField("triggers", triggers, allowedSliceStrings("amazons3", "gcs", "github", "gitlab", "bitbucket"))
+// See the Trigger constants for allowed values.
+func WithTriggers(triggers ...Trigger) ExportOption {
+	strs := make([]string, len(triggers))
+	for i, trigger := range triggers {
+		strs[i] = string(trigger)
+	}
+	return stringArrayField("triggers", strs, allowedSliceStrings(allowedValues["triggers"]...))
 }
 
 // WithRepos returns an ExportOption setting what repos to include when repo integrations are triggered.
@@ -188,15 +194,15 @@ func WithRepos(repos ...string) ExportOption {
 // WithPluralFormat returns an ExportOption overriding the default plural
 // format for the file type.
 //
-// Allowed values are "json_string", "icu", "array" and "generic".
-func WithPluralFormat(format string) ExportOption {
-	return stringField("plural_format", format, allowedStrings("json_string", "icu", "array", "generic", "symfony", "raw", "i18next"))
+// See the PluralFormat constants for allowed values.
+func WithPluralFormat(format PluralFormat) ExportOption {
+	return stringField("plural_format", string(format), allowedStrings(allowedValues["plural_format"]...))
 }
 
 // WithICUNumeric returns an ExportOption setting whether the plural forms
 // "zero", "one" and "two" is replaced with "=0", "=1", "=2" respectively.
 //
-// Only available for when setting WithPluralFormat("icu")
+// Only available for when setting WithPluralFormat(PluralICU).
 func WithICUNumeric(enabled bool) ExportOption {
 	return boolField("icu_numeric", enabled)
 }
@@ -209,19 +215,19 @@ func WithPercentEscape(enabled bool) ExportOption {
 	return boolField("escape_percent", enabled)
 }
 
-// WithIndentation returns an ExportOption overriding the default indentation
+// WithIndentation returns an ExportOption overriding the default indentation.
 //
-// Allowed values are "1sp", "2sp", "3sp", "4sp", "5sp", "6sp", "7sp", "8sp", "tab",
-func WithIndentation(format string) ExportOption {
-	return stringField("indentation", format, allowedStrings("1sp", "2sp", "3sp", "4sp", "5sp", "6sp", "7sp", "8sp", "tab"))
+// See the Indentation constants for allowed values.
+func WithIndentation(indentation Indentation) ExportOption {
+	return stringField("indentation", string(indentation), allowedStrings(allowedValues["indentation"]...))
 }
 
 // WithPlaceholderFormat returns an ExportOption overriding the default
 // placeholder format for the file type.
 //
-// Allowed values are "printf", "ios", "icu" and "net".
-func WithPlaceholderFormat(format string) ExportOption {
-	return stringField("placeholder_format", format, allowedStrings("printf", "ios", "icu", "net", "symfony", "raw", "i18n"))
+// See the PlaceholderFormat constants for allowed values.
+func WithPlaceholderFormat(format PlaceholderFormat) ExportOption {
+	return stringField("placeholder_format", string(format), allowedStrings(allowedValues["placeholder_format"]...))
 }
 
 func boolField(field string, value bool) ExportOption {
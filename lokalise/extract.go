@@ -0,0 +1,207 @@
+package lokalise
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrUnsafeBundleEntry is returned when a bundle entry would be written
+	// outside the destination directory or is not a regular file or directory.
+	ErrUnsafeBundleEntry = errors.New("lokalise: unsafe bundle entry")
+	// ErrBundleLimit is returned when a bundle exceeds an extraction limit.
+	ErrBundleLimit = errors.New("lokalise: bundle exceeds extraction limit")
+)
+
+// ExtractOption is a function setting options for a bundle extraction.
+type ExtractOption func(*extract) error
+
+type extract struct {
+	maxTotalSize int64
+	maxFileSize  int64
+	maxFiles     int
+}
+
+// WithMaxTotalSize returns an ExtractOption limiting the total uncompressed
+// size of all files in the bundle. Defaults to 1 GiB.
+func WithMaxTotalSize(size int64) ExtractOption {
+	return func(e *extract) error {
+		if size <= 0 {
+			return errors.New("lokalise: max total size must be positive")
+		}
+		e.maxTotalSize = size
+		return nil
+	}
+}
+
+// WithMaxFileSize returns an ExtractOption limiting the uncompressed size of
+// each file in the bundle. Defaults to 256 MiB.
+func WithMaxFileSize(size int64) ExtractOption {
+	return func(e *extract) error {
+		if size <= 0 {
+			return errors.New("lokalise: max file size must be positive")
+		}
+		e.maxFileSize = size
+		return nil
+	}
+}
+
+// WithMaxFiles returns an ExtractOption limiting the number of entries in the
+// bundle. Defaults to 10000.
+func WithMaxFiles(n int) ExtractOption {
+	return func(e *extract) error {
+		if n <= 0 {
+			return errors.New("lokalise: max files must be positive")
+		}
+		e.maxFiles = n
+		return nil
+	}
+}
+
+// ExtractedFile describes a file written by a bundle extraction.
+type ExtractedFile struct {
+	// Name is the slash separated name of the entry in the bundle.
+	Name string
+	// Path is the location the file was written to.
+	Path string
+	// Size is the number of bytes written.
+	Size int64
+	// Mode holds the permission bits the file was created with.
+	Mode os.FileMode
+}
+
+// ExtractBundleFile extracts the zip bundle at src into the directory dest.
+// See ExtractBundle for details.
+func ExtractBundleFile(src, dest string, opts ...ExtractOption) ([]ExtractedFile, error) {
+	r, err := zip.OpenReader(src)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return extractZip(&r.Reader, dest, opts)
+}
+
+// ExtractBundle extracts the zip bundle of the given size read from r into
+// the directory dest and returns the files written.
+//
+// Entries with absolute names or names escaping dest, as well as symbolic
+// links and other special files, are rejected with ErrUnsafeBundleEntry.
+// Bundles exceeding the extraction limits are rejected with ErrBundleLimit.
+// Files are created with their permission bits masked by 0755.
+//
+// On error the files written so far are returned along with the error.
+func ExtractBundle(r io.ReaderAt, size int64, dest string, opts ...ExtractOption) ([]ExtractedFile, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return extractZip(zr, dest, opts)
+}
+
+func extractZip(zr *zip.Reader, dest string, opts []ExtractOption) ([]ExtractedFile, error) {
+	e := extract{
+		maxTotalSize: 1 << 30,
+		maxFileSize:  256 << 20,
+		maxFiles:     10000,
+	}
+	for _, opt := range opts {
+		err := opt(&e)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(zr.File) > e.maxFiles {
+		return nil, fmt.Errorf("%w: %d entries, at most %d allowed", ErrBundleLimit, len(zr.File), e.maxFiles)
+	}
+
+	var files []ExtractedFile
+	var total int64
+	for _, f := range zr.File {
+		path, err := entryPath(dest, f.Name)
+		if err != nil {
+			return files, err
+		}
+		mode := f.Mode()
+		switch {
+		case mode.IsDir():
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return files, err
+			}
+			continue
+		case !mode.IsRegular():
+			return files, fmt.Errorf("%w: %q is not a regular file", ErrUnsafeBundleEntry, f.Name)
+		}
+
+		limit := e.maxFileSize
+		if left := e.maxTotalSize - total; left < limit {
+			limit = left
+		}
+		if f.UncompressedSize64 > uint64(limit) {
+			return files, fmt.Errorf("%w: %q is too large", ErrBundleLimit, f.Name)
+		}
+		perm := mode.Perm() & 0755
+		if perm == 0 {
+			perm = 0644
+		}
+		n, err := extractFile(f, path, perm, limit)
+		total += n
+		if err != nil {
+			return files, err
+		}
+		files = append(files, ExtractedFile{
+			Name: f.Name,
+			Path: path,
+			Size: n,
+			Mode: perm,
+		})
+	}
+	return files, nil
+}
+
+// entryPath returns the location of the bundle entry name within dest.
+func entryPath(dest, name string) (string, error) {
+	if name == "" || strings.HasPrefix(name, "/") || strings.HasPrefix(name, `\`) || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", fmt.Errorf("%w: %q is an absolute path", ErrUnsafeBundleEntry, name)
+	}
+	path := filepath.Join(dest, filepath.FromSlash(name))
+	rel, err := filepath.Rel(dest, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %q escapes the destination", ErrUnsafeBundleEntry, name)
+	}
+	return path, nil
+}
+
+// extractFile writes the bundle entry f to path, failing once more than
+// limit bytes are read regardless of the size the entry claims to have. On
+// failure the incomplete file is removed.
+func extractFile(f *zip.File, path string, perm os.FileMode, limit int64) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return 0, err
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(out, io.LimitReader(rc, limit+1))
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && n > limit {
+		err = fmt.Errorf("%w: %q is too large", ErrBundleLimit, f.Name)
+	}
+	if err != nil {
+		os.Remove(path)
+		return n, err
+	}
+	return n, nil
+}
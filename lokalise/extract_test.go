@@ -0,0 +1,218 @@
+package lokalise_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+type entry struct {
+	name    string
+	content string
+}
+
+// newBundle returns a zip bundle with the given entries.
+func newBundle(t *testing.T, entries ...entry) *bytes.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		w, err := zw.Create(e.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(e.content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+// listFiles returns the slash separated names of the regular files below dir.
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	var names []string
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			rel, _ := filepath.Rel(dir, path)
+			names = append(names, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	return names
+}
+
+func TestExtractBundle(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []entry
+		opts    []lokalise.ExtractOption
+		wantErr error
+		// want holds the files expected in the destination afterwards.
+		want []string
+	}{
+		{
+			name:    "bundle",
+			entries: []entry{{"locale/en.json", "{}"}, {"locale/", ""}, {"locale/de.json", "{}"}},
+			want:    []string{"locale/de.json", "locale/en.json"},
+		},
+		{
+			name:    "parent directory",
+			entries: []entry{{"locale/en.json", "{}"}, {"../evil.json", "{}"}},
+			wantErr: lokalise.ErrUnsafeBundleEntry,
+			want:    []string{"locale/en.json"},
+		},
+		{
+			name:    "escaping through subdirectory",
+			entries: []entry{{"locale/../../evil.json", "{}"}},
+			wantErr: lokalise.ErrUnsafeBundleEntry,
+		},
+		{
+			name:    "absolute path",
+			entries: []entry{{"/tmp/evil.json", "{}"}},
+			wantErr: lokalise.ErrUnsafeBundleEntry,
+		},
+		{
+			name:    "backslash absolute path",
+			entries: []entry{{`\evil.json`, "{}"}},
+			wantErr: lokalise.ErrUnsafeBundleEntry,
+		},
+		{
+			name:    "inner parent directory",
+			entries: []entry{{"locale/../en.json", "{}"}},
+			want:    []string{"en.json"},
+		},
+		{
+			name:    "file too large",
+			entries: []entry{{"en.json", "{}"}, {"de.json", strings.Repeat("x", 11)}},
+			opts:    []lokalise.ExtractOption{lokalise.WithMaxFileSize(10)},
+			wantErr: lokalise.ErrBundleLimit,
+			want:    []string{"en.json"},
+		},
+		{
+			name:    "file at size limit",
+			entries: []entry{{"en.json", strings.Repeat("x", 10)}},
+			opts:    []lokalise.ExtractOption{lokalise.WithMaxFileSize(10)},
+			want:    []string{"en.json"},
+		},
+		{
+			name:    "bundle too large",
+			entries: []entry{{"en.json", strings.Repeat("x", 6)}, {"de.json", strings.Repeat("x", 6)}},
+			opts:    []lokalise.ExtractOption{lokalise.WithMaxTotalSize(10)},
+			wantErr: lokalise.ErrBundleLimit,
+			want:    []string{"en.json"},
+		},
+		{
+			name:    "too many files",
+			entries: []entry{{"en.json", "{}"}, {"de.json", "{}"}},
+			opts:    []lokalise.ExtractOption{lokalise.WithMaxFiles(1)},
+			wantErr: lokalise.ErrBundleLimit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := ioutil.TempDir("", "lokalise")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(root)
+			dest := filepath.Join(root, "a", "b")
+
+			r := newBundle(t, tt.entries...)
+			files, err := lokalise.ExtractBundle(r, r.Size(), dest, tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if len(files) != len(tt.want) {
+				t.Errorf("got %d extracted files, want %d", len(files), len(tt.want))
+			}
+			// Nothing may be written outside of dest.
+			got := listFiles(t, root)
+			for i, name := range tt.want {
+				tt.want[i] = "a/b/" + name
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("files = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractBundleFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lokalise")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	src := filepath.Join(dir, "bundle.zip")
+	r := newBundle(t, entry{"locale/en.json", `{"greeting":"Hello"}`})
+	content, _ := ioutil.ReadAll(r)
+	if err := ioutil.WriteFile(src, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dest := filepath.Join(dir, "out")
+	files, err := lokalise.ExtractBundleFile(src, dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := lokalise.ExtractedFile{
+		Name: "locale/en.json",
+		Path: filepath.Join(dest, "locale", "en.json"),
+		Size: int64(len(`{"greeting":"Hello"}`)),
+		Mode: 0644,
+	}
+	if len(files) != 1 || files[0] != want {
+		t.Errorf("files = %+v, want [%+v]", files, want)
+	}
+
+	if _, err := lokalise.ExtractBundleFile(src, dest, lokalise.WithMaxFiles(0)); err == nil {
+		t.Error("got no error for an invalid limit")
+	}
+}
+
+func TestExtractBundleUnderstatedSize(t *testing.T) {
+	root, err := ioutil.TempDir("", "lokalise")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	// The central directory claims 5 bytes for an entry holding 11.
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: "en.json", Method: zip.Store})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte(strings.Repeat("x", 11)))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	dir := bytes.Index(b, []byte("PK\x01\x02"))
+	binary.LittleEndian.PutUint32(b[dir+24:], 5)
+
+	files, err := lokalise.ExtractBundle(bytes.NewReader(b), int64(len(b)), root, lokalise.WithMaxFileSize(10))
+	if err == nil {
+		t.Fatal("got no error")
+	}
+	if len(files) != 0 {
+		t.Errorf("got %d extracted files, want none", len(files))
+	}
+	if got := listFiles(t, root); len(got) != 0 {
+		t.Errorf("files = %q left behind, want none", got)
+	}
+}
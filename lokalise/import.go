@@ -2,13 +2,14 @@ package lokalise
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"io"
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // ImportResult represents the outcome of a file upload.
@@ -27,80 +28,210 @@ type importResponse struct {
 //
 // Customize the import by setting any ImportOptions.
 //
-// In case of API request errors an error of type Error is returned.
+// The file is streamed to the API, so memory use does not grow with the file size.
 func Import(apiToken, projectID, file, langISO string, opts ...ImportOption) (ImportResult, error) {
-	request, err := newfileUploadRequest(apiToken, projectID, file, langISO, opts...)
-	if err != nil {
-		return ImportResult{}, err
+	return ImportContext(context.Background(), apiToken, projectID, file, langISO, opts...)
+}
+
+// ImportContext is like Import but carries a context for cancellation and deadlines.
+// Cancelling the context aborts an upload in progress.
+func ImportContext(ctx context.Context, apiToken, projectID, file, langISO string, opts ...ImportOption) (ImportResult, error) {
+	return DefaultClient.withToken(apiToken).Import(ctx, projectID, file, langISO, opts...)
+}
+
+// Import uploads a file with translations in language langISO to a Lokalise project with ID projectID.
+//
+// See the package level Import for details.
+func (c *Client) Import(ctx context.Context, projectID, file, langISO string, opts ...ImportOption) (ImportResult, error) {
+	form := importForm(projectID, langISO, opts)
+	newRequest := func(u string) (*http.Request, error) {
+		return c.newFileUploadRequest(ctx, u, file, form)
 	}
-	resp, err := callAPI(request)
-	if err != nil {
-		return ImportResult{}, err
+	return c.importRequest(ctx, newRequest)
+}
+
+// ImportReader uploads translations in language langISO read from r to a Lokalise
+// project with ID projectID. The name is sent as the filename of the upload and
+// determines the file format, e.g. "en.json".
+//
+// If r implements io.Seeker and can seek, the upload starts at its current
+// offset and is rewound for retries. Otherwise, e.g. for pipes, r is read once
+// and the request is not retried.
+//
+// Customize the import by setting any ImportOptions.
+func (c *Client) ImportReader(ctx context.Context, projectID, name string, r io.Reader, langISO string, opts ...ImportOption) (ImportResult, error) {
+	rs, ok := r.(io.ReadSeeker)
+	var start int64
+	if ok {
+		// Pipes implement io.Seeker as *os.File but fail to seek.
+		var err error
+		start, err = rs.Seek(0, io.SeekCurrent)
+		ok = err == nil
 	}
-	if err := errorFromStatus(resp); err != nil {
-		return ImportResult{}, err
+	if !ok {
+		once := *c
+		once.retry.MaxAttempts = 1
+		newRequest := func(u string) (*http.Request, error) {
+			return c.newUploadRequest(ctx, u, name, ioutil.NopCloser(r), -1, importForm(projectID, langISO, opts))
+		}
+		return once.importRequest(ctx, newRequest)
 	}
-	body, err := ioutil.ReadAll(resp.Body)
-	defer resp.Body.Close()
 
-	var dat importResponse
-	if err := json.Unmarshal(body, &dat); err != nil {
+	end, err := rs.Seek(0, io.SeekEnd)
+	if err != nil {
 		return ImportResult{}, err
 	}
+	var prev chan struct{}
+	newRequest := func(u string) (*http.Request, error) {
+		// The body of a previous attempt may still be read from rs.
+		if prev != nil {
+			select {
+			case <-prev:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		if _, err := rs.Seek(start, io.SeekStart); err != nil {
+			return nil, err
+		}
+		done := make(chan struct{})
+		req, err := c.newUploadRequest(ctx, u, name, &signalCloser{Reader: rs, done: done}, end-start, importForm(projectID, langISO, opts))
+		if err != nil {
+			return nil, err
+		}
+		prev = done
+		return req, nil
+	}
+	return c.importRequest(ctx, newRequest)
+}
+
+// ImportBytes uploads the translations in content like ImportReader.
+func (c *Client) ImportBytes(ctx context.Context, projectID, name string, content []byte, langISO string, opts ...ImportOption) (ImportResult, error) {
+	return c.ImportReader(ctx, projectID, name, bytes.NewReader(content), langISO, opts...)
+}
 
-	if err := errorFromResponse(dat.Response); err != nil {
+func (c *Client) importRequest(ctx context.Context, newRequest func(u string) (*http.Request, error)) (ImportResult, error) {
+	var dat importResponse
+	if err := c.do(ctx, "project/import", newRequest, &dat); err != nil {
 		return ImportResult{}, err
 	}
 	return dat.Result, nil
 }
 
-func newfileUploadRequest(apiToken, projectID, path, langISO string, opts ...ImportOption) (*http.Request, error) {
-	file, err := os.Open(path)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
+// signalCloser closes done instead of the underlying reader, which is owned
+// by the caller.
+type signalCloser struct {
+	io.Reader
+	done chan struct{}
+}
 
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	part, err := writer.CreateFormFile("file", filepath.Base(path))
+func (r *signalCloser) Close() error {
+	close(r.done)
+	return nil
+}
 
-	if err != nil {
-		return nil, err
-	}
-	_, err = io.Copy(part, file)
-	if err != nil {
-		return nil, err
+// importForm returns the form fields of an import besides the file.
+func importForm(projectID, langISO string, opts []ImportOption) uploadForm {
+	return func(writer *multipart.Writer) error {
+		err := writer.WriteField("id", projectID)
+		if err != nil {
+			return err
+		}
+		err = writer.WriteField("lang_iso", langISO)
+		if err != nil {
+			return err
+		}
+		for _, opt := range opts {
+			err := opt(writer)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
 	}
+}
+
+// uploadForm writes the form fields of an upload besides the file and the
+// API token.
+type uploadForm func(*multipart.Writer) error
 
-	err = writer.WriteField("api_token", apiToken)
+// newFileUploadRequest returns an upload request streaming the file at path.
+func (c *Client) newFileUploadRequest(ctx context.Context, u, path string, form uploadForm) (*http.Request, error) {
+	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
-	err = writer.WriteField("id", projectID)
+	info, err := file.Stat()
 	if err != nil {
+		file.Close()
 		return nil, err
 	}
-	err = writer.WriteField("lang_iso", langISO)
+	req, err := c.newUploadRequest(ctx, u, filepath.Base(path), file, info.Size(), form)
 	if err != nil {
+		file.Close()
 		return nil, err
 	}
-	if opts != nil {
-		for _, opt := range opts {
-			err := opt(writer)
-			if err != nil {
-				return nil, err
-			}
+	return req, nil
+}
+
+// newUploadRequest returns an upload request streaming the multipart body
+// with the file name read from r, which is closed once the body is written.
+// If size is not negative it must be the number of bytes in r and the request
+// is sent with a known content length.
+func (c *Client) newUploadRequest(ctx context.Context, u, name string, r io.ReadCloser, size int64, form uploadForm) (*http.Request, error) {
+	fields := func(writer *multipart.Writer, content io.Reader) error {
+		part, err := writer.CreateFormFile("file", name)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(part, content)
+		if err != nil {
+			return err
 		}
+		err = writer.WriteField("api_token", c.apiToken)
+		if err != nil {
+			return err
+		}
+		err = form(writer)
+		if err != nil {
+			return err
+		}
+		return writer.Close()
 	}
-	err = writer.Close()
-	if err != nil {
+
+	// Write the body without file content first. This surfaces option errors
+	// before anything is sent and yields the size of the multipart framing.
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	counter := &countingWriter{}
+	dry := multipart.NewWriter(counter)
+	if err := dry.SetBoundary(writer.Boundary()); err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", api("project/import"), body)
+	if err := fields(dry, strings.NewReader("")); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u, pr)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Add("Content-Type", writer.FormDataContentType())
+	if size >= 0 {
+		req.ContentLength = counter.n + size
+	}
+	go func() {
+		defer r.Close()
+		pw.CloseWithError(fields(writer, r))
+	}()
 	return req, nil
 }
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -86,7 +86,8 @@ func WithTags(tags ...string) ImportOption {
 }
 
 // WithTagInsertedKeys returns an ImportOption setting a list of tags for inserted
-// keys.
+// keys. Find the inserted keys with WithKeyTags, e.g. to create a task with
+// CreateTask.
 func WithTagInsertedKeys(tags ...string) ImportOption {
 	return func(w *multipart.Writer) error {
 		jsonTags, err := json.Marshal(tags)
@@ -0,0 +1,254 @@
+package lokalise_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+	"github.com/lokalise/lokalise-cli-go/lokalise/lokalisetest"
+)
+
+const importContent = `{"greeting":"Hello","welcome":"Welcome"}`
+
+// writeTemp writes content to a file named name in a new temporary directory
+// and returns its path along with a function removing the directory.
+func writeTemp(t *testing.T, name string, content []byte) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "lokalise")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, content, 0644); err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestImportStreamsContentLength(t *testing.T) {
+	content := bytes.Repeat([]byte(" "), 1<<20)
+	copy(content, importContent)
+	file, cleanup := writeTemp(t, "en.json", content)
+	defer cleanup()
+
+	srv := newScripted(reply{body: importBody})
+	defer srv.Close()
+	c := newTestClient(t, srv.Server)
+
+	if _, err := c.Import(context.Background(), projectID, file, "en"); err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.received()
+	if len(reqs) != 1 {
+		t.Fatalf("got %d requests, want 1", len(reqs))
+	}
+	if reqs[0].ContentLength <= int64(len(content)) {
+		t.Errorf("content length = %d, want the known size of the multipart body", reqs[0].ContentLength)
+	}
+	if !bytes.Equal(reqs[0].File, content) {
+		t.Errorf("uploaded %d bytes, want %d", len(reqs[0].File), len(content))
+	}
+}
+
+func TestImportOptionError(t *testing.T) {
+	file, cleanup := writeTemp(t, "en.json", []byte(importContent))
+	defer cleanup()
+
+	srv := newScripted(reply{body: importBody})
+	defer srv.Close()
+	c := newTestClient(t, srv.Server)
+
+	errOption := errors.New("bad option")
+	var failing lokalise.ImportOption = func(*multipart.Writer) error { return errOption }
+	if _, err := c.Import(context.Background(), projectID, file, "en", failing); !errors.Is(err, errOption) {
+		t.Errorf("error = %v, want the error of the option", err)
+	}
+	if n := len(srv.received()); n != 0 {
+		t.Errorf("got %d requests, want none", n)
+	}
+}
+
+func TestImportMissingFile(t *testing.T) {
+	srv := newScripted(reply{body: importBody})
+	defer srv.Close()
+	c := newTestClient(t, srv.Server, fastRetries)
+
+	_, err := c.Import(context.Background(), projectID, filepath.Join(os.TempDir(), "lokalise-missing.json"), "en")
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("error = %v, want a missing file error", err)
+	}
+	if n := len(srv.received()); n != 0 {
+		t.Errorf("got %d requests, want none", n)
+	}
+}
+
+func TestImportReaderRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		upload func(context.Context, *lokalise.Client) (lokalise.ImportResult, error)
+	}{
+		{"ImportBytes", func(ctx context.Context, c *lokalise.Client) (lokalise.ImportResult, error) {
+			return c.ImportBytes(ctx, projectID, "en.json", []byte(importContent), "en", lokalise.WithReplace(true))
+		}},
+		{"ImportReader", func(ctx context.Context, c *lokalise.Client) (lokalise.ImportResult, error) {
+			// Uploads start at the current offset of a seeker.
+			r := strings.NewReader("ignored" + importContent)
+			r.Seek(int64(len("ignored")), io.SeekStart)
+			return c.ImportReader(ctx, projectID, "en.json", r, "en", lokalise.WithReplace(true))
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newScripted(reply{status: http.StatusServiceUnavailable}, reply{status: http.StatusBadGateway}, reply{body: importBody})
+			defer srv.Close()
+			c := newTestClient(t, srv.Server, fastRetries)
+
+			if _, err := tt.upload(context.Background(), c); err != nil {
+				t.Fatal(err)
+			}
+			reqs := srv.received()
+			if len(reqs) != 3 {
+				t.Fatalf("got %d attempts, want 3", len(reqs))
+			}
+			for i, req := range reqs {
+				if req.Filename != "en.json" || string(req.File) != importContent {
+					t.Errorf("attempt %d uploaded %s %q, want en.json %q", i+1, req.Filename, req.File, importContent)
+				}
+				if req.ContentLength != reqs[0].ContentLength || req.ContentLength < 0 {
+					t.Errorf("attempt %d sent content length %d, want %d", i+1, req.ContentLength, reqs[0].ContentLength)
+				}
+				if req.Form.Get("lang_iso") != "en" || req.Form.Get("replace") != "1" {
+					t.Errorf("attempt %d sent form %v", i+1, req.Form)
+				}
+			}
+		})
+	}
+}
+
+func TestImportReaderOnce(t *testing.T) {
+	srv := newScripted(reply{status: http.StatusBadGateway}, reply{body: importBody})
+	defer srv.Close()
+	c := newTestClient(t, srv.Server, fastRetries)
+
+	// A reader that cannot seek is read once, so the request is not retried.
+	r := io.MultiReader(strings.NewReader(importContent))
+	if _, err := c.ImportReader(context.Background(), projectID, "en.json", r, "en"); err == nil {
+		t.Fatal("got no error, want the failure of the single attempt")
+	}
+	if n := len(srv.received()); n != 1 {
+		t.Errorf("got %d attempts, want 1", n)
+	}
+
+	r = io.MultiReader(strings.NewReader(importContent))
+	if _, err := c.ImportReader(context.Background(), projectID, "en.json", r, "en"); err != nil {
+		t.Fatal(err)
+	}
+	if req := srv.received()[1]; string(req.File) != importContent {
+		t.Errorf("uploaded %q, want %q", req.File, importContent)
+	}
+}
+
+func TestImportReaderPipe(t *testing.T) {
+	srv := newScripted(reply{status: http.StatusBadGateway}, reply{body: importBody})
+	defer srv.Close()
+	c := newTestClient(t, srv.Server, fastRetries)
+
+	pipe := func() *os.File {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		go func() {
+			w.Write([]byte(importContent))
+			w.Close()
+		}()
+		return r
+	}
+
+	// A pipe implements io.Seeker but cannot seek, so it is read once.
+	r := pipe()
+	defer r.Close()
+	if _, err := c.ImportReader(context.Background(), projectID, "en.json", r, "en"); err == nil {
+		t.Fatal("got no error, want the failure of the single attempt")
+	}
+	if n := len(srv.received()); n != 1 {
+		t.Errorf("got %d attempts, want 1", n)
+	}
+
+	r = pipe()
+	defer r.Close()
+	if _, err := c.ImportReader(context.Background(), projectID, "en.json", r, "en"); err != nil {
+		t.Fatal(err)
+	}
+	if req := srv.received()[1]; string(req.File) != importContent || req.ContentLength >= 0 {
+		t.Errorf("uploaded %q with content length %d, want %q streamed", req.File, req.ContentLength, importContent)
+	}
+}
+
+// slowReader is an endless source of spaces trickling in, closing started
+// after its first read.
+type slowReader struct {
+	once    sync.Once
+	started chan struct{}
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	r.once.Do(func() { close(r.started) })
+	time.Sleep(time.Millisecond)
+	if len(p) > 1024 {
+		p = p[:1024]
+	}
+	for i := range p {
+		p[i] = ' '
+	}
+	return len(p), nil
+}
+
+// uploading reports whether a goroutine writing an upload body is running.
+func uploading() bool {
+	buf := make([]byte, 1<<20)
+	n := runtime.Stack(buf, true)
+	return bytes.Contains(buf[:n], []byte("lokalise.(*Client).newUploadRequest"))
+}
+
+func TestImportCanceledMidUpload(t *testing.T) {
+	srv := lokalisetest.NewServer()
+	defer srv.Close()
+	srv.AddProject(lokalisetest.Project{ID: projectID, Name: "App", Languages: []string{"en"}})
+	c, err := srv.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := &slowReader{started: make(chan struct{})}
+	go func() {
+		<-r.started
+		cancel()
+	}()
+	if _, err := c.ImportReader(ctx, projectID, "en.json", r, "en"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for uploading() {
+		if time.Now().After(deadline) {
+			t.Fatal("the goroutine writing the upload body is still running")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,147 @@
+package lokalise
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+)
+
+// Key is the data model for a translation key.
+type Key struct {
+	// ID is assigned by the API when the key is created.
+	ID          int64      `json:"key_id,omitempty"`
+	Name        string     `json:"key_name,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Context     string     `json:"context,omitempty"`
+	Platforms   []Platform `json:"platforms,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	// Filename is the file the key is exported to with WithOriginal(true).
+	Filename string `json:"filename,omitempty"`
+	// Plural marks a plural key. Its Translations hold the plural forms as
+	// JSON objects, use ListTranslations to get them decoded.
+	Plural bool `json:"is_plural,omitempty"`
+	// Translations maps language ISO codes to translations.
+	Translations map[string]string `json:"translations,omitempty"`
+}
+
+// KeyPage is a page of keys returned by ListKeys.
+type KeyPage struct {
+	Keys []Key `json:"keys"`
+	Pagination
+}
+
+// WithKeyTags returns a ListOption limiting keys to those with any of the
+// given tags. For ListKeys only.
+func WithKeyTags(tags ...string) ListOption {
+	return ListOption(stringArrayField("filter_tags", tags))
+}
+
+// WithKeyFilenames returns a ListOption limiting keys to those assigned to
+// any of the given filenames. For ListKeys only.
+func WithKeyFilenames(filenames ...string) ListOption {
+	return ListOption(stringArrayField("filter_filenames", filenames))
+}
+
+// WithKeyPlatforms returns a ListOption limiting keys to those assigned to
+// any of the given platforms. For ListKeys only.
+func WithKeyPlatforms(platforms ...Platform) ListOption {
+	strs := make([]string, len(platforms))
+	for i, platform := range platforms {
+		strs[i] = string(platform)
+	}
+	return ListOption(stringArrayField("filter_platforms", strs, allowedSliceStrings(allowedValues["platforms"]...)))
+}
+
+type keyListResponse struct {
+	KeyPage
+	Response response `json:"response"`
+}
+
+type keysResponse struct {
+	Keys     []Key    `json:"keys"`
+	Response response `json:"response"`
+}
+
+// ListKeys returns a page of keys of project with ID projectID, the first one
+// unless WithPage is set.
+func (c *Client) ListKeys(ctx context.Context, projectID string, opts ...ListOption) (KeyPage, error) {
+	form, err := listForm(projectID, opts)
+	if err != nil {
+		return KeyPage{}, err
+	}
+	var dat keyListResponse
+	if err := c.do(ctx, "key/list", c.formRequest(ctx, form), &dat); err != nil {
+		return KeyPage{}, err
+	}
+	return dat.KeyPage, nil
+}
+
+// CreateKeys creates keys in project with ID projectID in a single request
+// and returns them with their IDs. Each key needs a name and at least one
+// platform.
+func (c *Client) CreateKeys(ctx context.Context, projectID string, keys []Key) ([]Key, error) {
+	for _, k := range keys {
+		if k.Name == "" || len(k.Platforms) == 0 {
+			return nil, errors.New("lokalise: keys to create need a name and a platform")
+		}
+		if err := checkPlatforms(k.Platforms); err != nil {
+			return nil, err
+		}
+	}
+	return c.keys(ctx, "key/create", projectID, keys, c.doUnlessProcessed)
+}
+
+// UpdateKeys updates keys of project with ID projectID, identified by their
+// ID, in a single request and returns them. Fields with zero values are left
+// unchanged.
+func (c *Client) UpdateKeys(ctx context.Context, projectID string, keys []Key) ([]Key, error) {
+	for _, k := range keys {
+		if k.ID == 0 {
+			return nil, errors.New("lokalise: keys to update need an ID")
+		}
+		if err := checkPlatforms(k.Platforms); err != nil {
+			return nil, err
+		}
+	}
+	return c.keys(ctx, "key/update", projectID, keys, c.do)
+}
+
+// DeleteKeys deletes the keys with the given IDs from project with ID
+// projectID in a single request.
+func (c *Client) DeleteKeys(ctx context.Context, projectID string, ids ...int64) error {
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+	form := url.Values{}
+	form.Set("id", projectID)
+	form.Set("keys", string(data))
+	return c.doUnlessProcessed(ctx, "key/delete", c.formRequest(ctx, form), &struct{}{})
+}
+
+// keys sends keys to endpoint with do.
+func (c *Client) keys(ctx context.Context, endpoint, projectID string, keys []Key, do doFunc) ([]Key, error) {
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{}
+	form.Set("id", projectID)
+	form.Set("keys", string(data))
+	var dat keysResponse
+	if err := do(ctx, endpoint, c.formRequest(ctx, form), &dat); err != nil {
+		return nil, err
+	}
+	return dat.Keys, nil
+}
+
+func checkPlatforms(platforms []Platform) error {
+	allowed := allowedSliceStrings(allowedValues["platforms"]...)
+	for _, platform := range platforms {
+		if err := allowed([]string{string(platform)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package lokalise_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+	"github.com/lokalise/lokalise-cli-go/lokalise/lokalisetest"
+)
+
+func TestKeys(t *testing.T) {
+	srv, c := newFake(t, lokalisetest.Project{
+		ID:        projectID,
+		Name:      "App",
+		Admin:     true,
+		Languages: []string{"en"},
+		Keys: []lokalisetest.Key{
+			{Name: "greeting", Tags: []string{"web"}, Platforms: []string{"web"}, Translations: map[string]string{"en": "Hello"}},
+		},
+	})
+	defer srv.Close()
+	ctx := context.Background()
+
+	created, err := c.CreateKeys(ctx, projectID, []lokalise.Key{
+		{Name: "farewell", Platforms: []lokalise.Platform{lokalise.PlatformIOS}, Tags: []string{"ios"}, Translations: map[string]string{"en": "Bye"}},
+		{Name: "welcome", Platforms: []lokalise.Platform{lokalise.PlatformWeb}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 2 || created[0].ID == 0 || created[0].Name != "farewell" || created[1].Name != "welcome" {
+		t.Fatalf("created keys %+v", created)
+	}
+
+	page, err := c.ListKeys(ctx, projectID, lokalise.WithPage(1, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Keys) != 2 || page.TotalCount != 3 || !page.Next() {
+		t.Errorf("first page = %+v, want 2 of 3 keys", page)
+	}
+	page, err = c.ListKeys(ctx, projectID, lokalise.WithKeyPlatforms(lokalise.PlatformWeb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Keys) != 2 {
+		t.Errorf("web keys = %+v, want greeting and welcome", page.Keys)
+	}
+	page, err = c.ListKeys(ctx, projectID, lokalise.WithKeyTags("ios"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Keys) != 1 || page.Keys[0].Translations["en"] != "Bye" {
+		t.Errorf("ios keys = %+v, want farewell", page.Keys)
+	}
+
+	updated, err := c.UpdateKeys(ctx, projectID, []lokalise.Key{{ID: created[1].ID, Description: "Shown on the start page"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated) != 1 || updated[0].Name != "welcome" || updated[0].Description != "Shown on the start page" {
+		t.Errorf("updated keys %+v, want the description of welcome changed", updated)
+	}
+
+	if err := c.DeleteKeys(ctx, projectID, created[0].ID, created[1].ID); err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := srv.Project(projectID); len(p.Keys) != 1 || p.Keys[0].Name != "greeting" {
+		t.Errorf("keys = %+v, want greeting only", p.Keys)
+	}
+}
+
+func TestCreateKeysUnknownPlatform(t *testing.T) {
+	srv, c := newFake(t, lokalisetest.Project{ID: projectID, Name: "App", Admin: true, Languages: []string{"en"}})
+	defer srv.Close()
+
+	if _, err := c.CreateKeys(context.Background(), projectID, []lokalise.Key{{Name: "greeting", Platforms: []lokalise.Platform{"tv"}}}); err == nil {
+		t.Error("got no error")
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Errorf("got %d requests, want none", n)
+	}
+}
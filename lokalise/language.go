@@ -0,0 +1,109 @@
+package lokalise
+
+import (
+	"context"
+	"net/url"
+)
+
+// Language is the data model for a language of a project.
+type Language struct {
+	// ISO is the language code, e.g. "en" or "pt_BR".
+	ISO  string `json:"iso"`
+	Name string `json:"name"`
+	// Progress is the percentage of translated keys.
+	Progress int `json:"progress"`
+	// PluralForms lists the plural forms of the language, e.g. "one" and
+	// "other".
+	PluralForms []string `json:"plural_forms"`
+	RTL         bool     `json:"rtl"`
+}
+
+// pluralForms are the plural forms known to the API.
+var pluralForms = []string{"zero", "one", "two", "few", "many", "other"}
+
+// LanguageOption is a function setting options for adding or updating a
+// language.
+type LanguageOption func(*url.Values) error
+
+// WithCustomISO returns a LanguageOption overriding the language code, e.g.
+// for a market specific variant like "en_IE" of a standard language.
+func WithCustomISO(iso string) LanguageOption {
+	return LanguageOption(stringField("custom_iso", iso))
+}
+
+// WithCustomName returns a LanguageOption overriding the language name.
+func WithCustomName(name string) LanguageOption {
+	return LanguageOption(stringField("custom_name", name))
+}
+
+// WithCustomPluralForms returns a LanguageOption overriding the plural forms
+// of the language. Allowed values are "zero", "one", "two", "few", "many" and
+// "other".
+func WithCustomPluralForms(forms ...string) LanguageOption {
+	return LanguageOption(stringArrayField("custom_plural_forms", forms, allowedSliceStrings(pluralForms...)))
+}
+
+type languagesResponse struct {
+	Languages []Language `json:"languages"`
+	Response  response   `json:"response"`
+}
+
+type languageResponse struct {
+	Language Language `json:"language"`
+	Response response `json:"response"`
+}
+
+// ListLanguages returns the languages of project with ID projectID along with
+// their translation progress.
+func (c *Client) ListLanguages(ctx context.Context, projectID string) ([]Language, error) {
+	form := url.Values{}
+	form.Set("id", projectID)
+	var dat languagesResponse
+	if err := c.do(ctx, "language/list", c.formRequest(ctx, form), &dat); err != nil {
+		return nil, err
+	}
+	return dat.Languages, nil
+}
+
+// AddLanguage adds the language with code iso to project with ID projectID
+// and returns it. Customize the language with LanguageOptions.
+//
+// If the language is already in the project an error matching
+// ErrLanguageExist is returned, for an unknown code one matching
+// ErrWrongLanguageCode.
+func (c *Client) AddLanguage(ctx context.Context, projectID, iso string, opts ...LanguageOption) (Language, error) {
+	return c.language(ctx, "language/add", projectID, iso, opts, c.doUnlessProcessed)
+}
+
+// UpdateLanguage changes the language with code iso of project with ID
+// projectID as set by opts and returns it.
+func (c *Client) UpdateLanguage(ctx context.Context, projectID, iso string, opts ...LanguageOption) (Language, error) {
+	return c.language(ctx, "language/update", projectID, iso, opts, c.do)
+}
+
+// RemoveLanguage removes the language with code iso and all its translations
+// from project with ID projectID.
+func (c *Client) RemoveLanguage(ctx context.Context, projectID, iso string) error {
+	form := url.Values{}
+	form.Set("id", projectID)
+	form.Set("lang_iso", iso)
+	return c.doUnlessProcessed(ctx, "language/remove", c.formRequest(ctx, form), &struct{}{})
+}
+
+// language sends the language with code iso and opts applied to endpoint
+// with do.
+func (c *Client) language(ctx context.Context, endpoint, projectID, iso string, opts []LanguageOption, do doFunc) (Language, error) {
+	form := &url.Values{}
+	form.Set("id", projectID)
+	form.Set("lang_iso", iso)
+	for _, opt := range opts {
+		if err := opt(form); err != nil {
+			return Language{}, err
+		}
+	}
+	var dat languageResponse
+	if err := do(ctx, endpoint, c.formRequest(ctx, *form), &dat); err != nil {
+		return Language{}, err
+	}
+	return dat.Language, nil
+}
@@ -0,0 +1,76 @@
+package lokalise_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+	"github.com/lokalise/lokalise-cli-go/lokalise/lokalisetest"
+)
+
+func TestLanguages(t *testing.T) {
+	srv, c := newFake(t, lokalisetest.Project{
+		ID:        projectID,
+		Name:      "App",
+		Admin:     true,
+		Languages: []string{"en"},
+		Keys: []lokalisetest.Key{
+			{Name: "greeting", Translations: map[string]string{"en": "Hello"}},
+		},
+	})
+	defer srv.Close()
+	ctx := context.Background()
+
+	l, err := c.AddLanguage(ctx, projectID, "en", lokalise.WithCustomISO("en_IE"), lokalise.WithCustomName("Irish English"), lokalise.WithCustomPluralForms("one", "other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.ISO != "en_IE" || l.Name != "Irish English" || len(l.PluralForms) != 2 {
+		t.Errorf("added language %+v", l)
+	}
+
+	languages, err := c.ListLanguages(ctx, projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(languages) != 2 || languages[0].ISO != "en" || languages[0].Progress != 100 || languages[1].ISO != "en_IE" || languages[1].Progress != 0 {
+		t.Errorf("languages = %+v, want en translated and en_IE empty", languages)
+	}
+
+	l, err = c.UpdateLanguage(ctx, projectID, "en_IE", lokalise.WithCustomISO("ga"), lokalise.WithCustomName("Irish"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.ISO != "ga" || l.Name != "Irish" {
+		t.Errorf("updated language %+v", l)
+	}
+
+	if err := c.RemoveLanguage(ctx, projectID, "ga"); err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := srv.Project(projectID); len(p.Languages) != 1 {
+		t.Errorf("languages = %v, want en only", p.Languages)
+	}
+}
+
+func TestLanguageErrors(t *testing.T) {
+	srv, c := newFake(t, lokalisetest.Project{ID: projectID, Name: "App", Admin: true, Languages: []string{"en"}})
+	defer srv.Close()
+	ctx := context.Background()
+
+	if _, err := c.AddLanguage(ctx, projectID, "en"); !errors.Is(err, lokalise.ErrLanguageExist) {
+		t.Errorf("existing language: error = %v, want ErrLanguageExist", err)
+	}
+	if err := c.RemoveLanguage(ctx, projectID, "fr"); !errors.Is(err, lokalise.ErrLanguageNotAvailable) {
+		t.Errorf("missing language: error = %v, want ErrLanguageNotAvailable", err)
+	}
+
+	// Unknown plural forms are rejected before sending the request.
+	if _, err := c.AddLanguage(ctx, projectID, "fr", lokalise.WithCustomPluralForms("lots")); err == nil {
+		t.Error("unknown plural form: got no error")
+	}
+	if n := len(srv.Requests()); n != 2 {
+		t.Errorf("got %d requests, want 2", n)
+	}
+}
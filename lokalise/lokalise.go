@@ -3,10 +3,20 @@
 //
 // An API token is at minimum required. Information on how to generate one can be found at the
 // web API documentation at https://lokalise.co/apidocs.
+//
+// The package level functions use the shared DefaultClient. Create a Client with NewClient to
+// customize the HTTP transport, timeouts or the API location.
+//
+// Errors reported by the API carry a Code. Test for one with errors.Is and the
+// matching sentinel, e.g. errors.Is(err, ErrRateLimit), or use errors.As with
+// *Error to read the code and message. Responses with an HTTP status other
+// than 200 fail with *HTTPError, which holds the status, headers and the start
+// of the body, and matches ErrCustom. Network failures and undecodable
+// responses are returned wrapped, so errors.Is and errors.As see their cause,
+// e.g. context.Canceled. IsRetryable and IsAuth classify any of these errors.
 package lokalise
 
 import (
-	"net/http"
 	"strings"
 	"time"
 )
@@ -18,17 +28,6 @@ const (
 	timeFormat = "2006-01-02 15:04:05"
 )
 
-func callAPI(req *http.Request) (*http.Response, error) {
-	client := http.Client{
-		Timeout: timeout,
-	}
-	return client.Do(req)
-}
-
-func api(path string) string {
-	return baseURL + path
-}
-
 type response struct {
 	Status  string `json:"status"`
 	Code    Code   `json:"code"`
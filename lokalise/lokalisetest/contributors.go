@@ -0,0 +1,123 @@
+package lokalisetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+// apiContributor converts c to the API model.
+func (c *Contributor) apiContributor() lokalise.Contributor {
+	contributor := lokalise.Contributor{
+		ID:        c.ID,
+		Email:     c.Email,
+		Name:      c.Name,
+		Admin:     c.Admin,
+		Reviewer:  c.Reviewer,
+		Languages: []lokalise.LanguagePermission{},
+	}
+	isos := make([]string, 0, len(c.Languages))
+	for iso := range c.Languages {
+		isos = append(isos, iso)
+	}
+	sort.Strings(isos)
+	for _, iso := range isos {
+		contributor.Languages = append(contributor.Languages, lokalise.LanguagePermission{ISO: iso, Writable: c.Languages[iso]})
+	}
+	return contributor
+}
+
+func (p *Project) contributor(id int64) *Contributor {
+	for i := range p.Contributors {
+		if p.Contributors[i].ID == id {
+			return &p.Contributors[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) contributors(w http.ResponseWriter, endpoint string, req Request) {
+	p := s.project(req.Form.Get("id"))
+	if p == nil {
+		writeError(w, lokalise.AccessDenied, "Project not found")
+		return
+	}
+	if endpoint == "contributor/list" {
+		contributors := []lokalise.Contributor{}
+		for i := range p.Contributors {
+			contributors = append(contributors, p.Contributors[i].apiContributor())
+		}
+		writeJSON(w, map[string]interface{}{"contributors": contributors})
+		return
+	}
+
+	var c *Contributor
+	if endpoint == "contributor/add" {
+		email := req.Form.Get("email")
+		if !strings.Contains(email, "@") {
+			writeError(w, lokalise.Custom, "Invalid email")
+			return
+		}
+		for _, existing := range p.Contributors {
+			if existing.Email == email {
+				writeError(w, lokalise.Custom, fmt.Sprintf("%s is already a contributor", email))
+				return
+			}
+		}
+	} else {
+		id, _ := strconv.ParseInt(req.Form.Get("user_id"), 10, 64)
+		if c = p.contributor(id); c == nil {
+			writeError(w, lokalise.Custom, fmt.Sprintf("Contributor %s not found", req.Form.Get("user_id")))
+			return
+		}
+	}
+	if endpoint == "contributor/remove" {
+		for i := range p.Contributors {
+			if p.Contributors[i].ID == c.ID {
+				p.Contributors = append(p.Contributors[:i], p.Contributors[i+1:]...)
+				break
+			}
+		}
+		writeJSON(w, map[string]interface{}{})
+		return
+	}
+
+	var languages []lokalise.LanguagePermission
+	if v := req.Form.Get("languages"); v != "" {
+		if err := json.Unmarshal([]byte(v), &languages); err != nil {
+			writeError(w, lokalise.NotJSON, "Invalid languages")
+			return
+		}
+		for _, l := range languages {
+			if !p.hasLanguage(l.ISO) {
+				writeError(w, lokalise.LanguageNotAvailable, fmt.Sprintf("Language %s is not available", l.ISO))
+				return
+			}
+		}
+	}
+	if c == nil {
+		p.Contributors = append(p.Contributors, Contributor{ID: s.nextID(), Email: req.Form.Get("email"), Languages: map[string]bool{}})
+		c = &p.Contributors[len(p.Contributors)-1]
+	}
+	if name := req.Form.Get("fullname"); name != "" {
+		c.Name = name
+	}
+	if v, ok := req.Form["is_admin"]; ok {
+		c.Admin = v[0] == "1"
+	}
+	if v, ok := req.Form["is_reviewer"]; ok {
+		c.Reviewer = v[0] == "1"
+	}
+	if languages != nil {
+		c.Languages = map[string]bool{}
+		for _, l := range languages {
+			c.Languages[l.ISO] = l.Writable
+		}
+	}
+	writeJSON(w, map[string]interface{}{"contributor": c.apiContributor()})
+}
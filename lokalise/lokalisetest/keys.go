@@ -0,0 +1,227 @@
+package lokalisetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+// apiKey converts k to the API model.
+func (k *Key) apiKey() lokalise.Key {
+	key := lokalise.Key{
+		ID:           k.ID,
+		Name:         k.Name,
+		Description:  k.Description,
+		Context:      k.Context,
+		Tags:         append([]string(nil), k.Tags...),
+		Filename:     k.Filename,
+		Plural:       k.Plural,
+		Translations: map[string]string{},
+	}
+	for _, platform := range k.Platforms {
+		key.Platforms = append(key.Platforms, lokalise.Platform(platform))
+	}
+	for iso, t := range k.Translations {
+		key.Translations[iso] = t
+	}
+	return key
+}
+
+// apply sets the non-zero fields of key on k.
+func (k *Key) apply(key lokalise.Key) {
+	if key.Name != "" {
+		k.Name = key.Name
+	}
+	if key.Description != "" {
+		k.Description = key.Description
+	}
+	if key.Context != "" {
+		k.Context = key.Context
+	}
+	if len(key.Platforms) > 0 {
+		k.Platforms = nil
+		for _, platform := range key.Platforms {
+			k.Platforms = append(k.Platforms, string(platform))
+		}
+	}
+	if len(key.Tags) > 0 {
+		k.Tags = append([]string(nil), key.Tags...)
+	}
+	if key.Filename != "" {
+		k.Filename = key.Filename
+	}
+	if key.Plural {
+		k.Plural = true
+	}
+	for iso, t := range key.Translations {
+		if k.Translations == nil {
+			k.Translations = map[string]string{}
+		}
+		k.Translations[iso] = t
+	}
+}
+
+func (p *Project) keyByID(id int64) *Key {
+	for i := range p.Keys {
+		if p.Keys[i].ID == id {
+			return &p.Keys[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) listKeys(w http.ResponseWriter, req Request) {
+	p := s.project(req.Form.Get("id"))
+	if p == nil {
+		writeError(w, lokalise.AccessDenied, "Project not found")
+		return
+	}
+	var tags, filenames, platforms []string
+	for field, dst := range map[string]*[]string{
+		"filter_tags":      &tags,
+		"filter_filenames": &filenames,
+		"filter_platforms": &platforms,
+	} {
+		if v := req.Form.Get(field); v != "" {
+			if err := json.Unmarshal([]byte(v), dst); err != nil {
+				writeError(w, lokalise.Custom, fmt.Sprintf("Invalid %s", field))
+				return
+			}
+		}
+	}
+	page, limit := 1, 100
+	if v := req.Form.Get("page"); v != "" {
+		page, _ = strconv.Atoi(v)
+	}
+	if v := req.Form.Get("limit"); v != "" {
+		limit, _ = strconv.Atoi(v)
+	}
+	if page < 1 || limit < 1 {
+		writeError(w, lokalise.Custom, "Invalid page or limit")
+		return
+	}
+
+	var keys []lokalise.Key
+	for i := range p.Keys {
+		k := &p.Keys[i]
+		if len(tags) > 0 && !k.hasAnyTag(tags) {
+			continue
+		}
+		if len(filenames) > 0 && !contains(filenames, k.Filename) {
+			continue
+		}
+		if len(platforms) > 0 && !containsAny(platforms, k.Platforms) {
+			continue
+		}
+		keys = append(keys, k.apiKey())
+	}
+	total := len(keys)
+	start, end := (page-1)*limit, page*limit
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+	writeJSON(w, map[string]interface{}{
+		"keys":        append([]lokalise.Key{}, keys[start:end]...),
+		"page":        page,
+		"page_count":  (total + limit - 1) / limit,
+		"total_count": total,
+	})
+}
+
+func (s *Server) changeKeys(w http.ResponseWriter, endpoint string, req Request) {
+	p := s.project(req.Form.Get("id"))
+	if p == nil {
+		writeError(w, lokalise.AccessDenied, "Project not found")
+		return
+	}
+	if endpoint == "key/delete" {
+		var ids []int64
+		if err := json.Unmarshal([]byte(req.Form.Get("keys")), &ids); err != nil {
+			writeError(w, lokalise.NotJSON, "Invalid keys")
+			return
+		}
+		for _, id := range ids {
+			if p.keyByID(id) == nil {
+				writeError(w, lokalise.Custom, fmt.Sprintf("Key %d not found", id))
+				return
+			}
+		}
+		kept := p.Keys[:0]
+		for _, k := range p.Keys {
+			if !containsID(ids, k.ID) {
+				kept = append(kept, k)
+			}
+		}
+		p.Keys = kept
+		writeJSON(w, map[string]interface{}{})
+		return
+	}
+
+	var keys []lokalise.Key
+	if err := json.Unmarshal([]byte(req.Form.Get("keys")), &keys); err != nil {
+		writeError(w, lokalise.NotJSON, "Invalid keys")
+		return
+	}
+	for _, key := range keys {
+		switch {
+		case endpoint == "key/create" && p.key(key.Name) != nil:
+			writeError(w, lokalise.Custom, fmt.Sprintf("Key %s already exists", key.Name))
+			return
+		case endpoint == "key/update" && p.keyByID(key.ID) == nil:
+			writeError(w, lokalise.Custom, fmt.Sprintf("Key %d not found", key.ID))
+			return
+		}
+		for iso := range key.Translations {
+			if !p.hasLanguage(iso) {
+				writeError(w, lokalise.LanguageNotAvailable, fmt.Sprintf("Language %s is not available", iso))
+				return
+			}
+		}
+	}
+	result := []lokalise.Key{}
+	for _, key := range keys {
+		var k *Key
+		if endpoint == "key/create" {
+			p.Keys = append(p.Keys, Key{ID: s.nextID()})
+			k = &p.Keys[len(p.Keys)-1]
+		} else {
+			k = p.keyByID(key.ID)
+		}
+		k.apply(key)
+		result = append(result, k.apiKey())
+	}
+	writeJSON(w, map[string]interface{}{"keys": result})
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(values, others []string) bool {
+	for _, other := range others {
+		if contains(values, other) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package lokalisetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+// language returns the language with code iso as served by the language
+// endpoints. Progress is the percentage of keys with a translation.
+func (p *Project) language(iso string) map[string]interface{} {
+	name := p.LanguageNames[iso]
+	if name == "" {
+		name = iso
+	}
+	progress := 0
+	if len(p.Keys) > 0 {
+		translated := 0
+		for _, k := range p.Keys {
+			if k.Translations[iso] != "" {
+				translated++
+			}
+		}
+		progress = translated * 100 / len(p.Keys)
+	}
+	return map[string]interface{}{
+		"iso":          iso,
+		"name":         name,
+		"progress":     progress,
+		"plural_forms": []string{"one", "other"},
+		"rtl":          false,
+	}
+}
+
+func (s *Server) languages(w http.ResponseWriter, endpoint string, req Request) {
+	p := s.project(req.Form.Get("id"))
+	if p == nil {
+		writeError(w, lokalise.AccessDenied, "Project not found")
+		return
+	}
+	if endpoint == "language/list" {
+		languages := []map[string]interface{}{}
+		for _, iso := range p.Languages {
+			languages = append(languages, p.language(iso))
+		}
+		writeJSON(w, map[string]interface{}{"languages": languages})
+		return
+	}
+
+	iso := req.Form.Get("lang_iso")
+	if iso == "" {
+		writeError(w, lokalise.LanguageNotSpecified, "Language not specified")
+		return
+	}
+	if strings.ContainsAny(iso, " /") {
+		writeError(w, lokalise.WrongLanguageCode, fmt.Sprintf("Wrong language code %s", iso))
+		return
+	}
+	if custom := req.Form.Get("custom_iso"); custom != "" && endpoint == "language/add" {
+		iso = custom
+	}
+	exists := p.hasLanguage(iso)
+	switch {
+	case endpoint == "language/add" && exists:
+		writeError(w, lokalise.LanguageExist, fmt.Sprintf("Language %s already exists", iso))
+		return
+	case endpoint != "language/add" && !exists:
+		writeError(w, lokalise.LanguageNotAvailable, fmt.Sprintf("Language %s is not available", iso))
+		return
+	}
+
+	if p.LanguageNames == nil {
+		p.LanguageNames = map[string]string{}
+	}
+	switch endpoint {
+	case "language/add":
+		p.Languages = append(p.Languages, iso)
+	case "language/update":
+		if custom := req.Form.Get("custom_iso"); custom != "" && custom != iso {
+			for i, l := range p.Languages {
+				if l == iso {
+					p.Languages[i] = custom
+				}
+			}
+			for i := range p.Keys {
+				if t, ok := p.Keys[i].Translations[iso]; ok {
+					delete(p.Keys[i].Translations, iso)
+					p.Keys[i].Translations[custom] = t
+				}
+			}
+			p.LanguageNames[custom] = p.LanguageNames[iso]
+			delete(p.LanguageNames, iso)
+			iso = custom
+		}
+	case "language/remove":
+		for i, l := range p.Languages {
+			if l == iso {
+				p.Languages = append(p.Languages[:i], p.Languages[i+1:]...)
+				break
+			}
+		}
+		for i := range p.Keys {
+			delete(p.Keys[i].Translations, iso)
+		}
+		delete(p.LanguageNames, iso)
+		writeJSON(w, map[string]interface{}{})
+		return
+	}
+	if name := req.Form.Get("custom_name"); name != "" {
+		p.LanguageNames[iso] = name
+	}
+	language := p.language(iso)
+	if forms := req.Form.Get("custom_plural_forms"); forms != "" {
+		var pluralForms []string
+		json.Unmarshal([]byte(forms), &pluralForms)
+		language["plural_forms"] = pluralForms
+	}
+	writeJSON(w, map[string]interface{}{"language": language})
+}
@@ -0,0 +1,341 @@
+package lokalisetest
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+// Project is an in-memory Lokalise project served by a Server.
+type Project struct {
+	ID          string
+	Name        string
+	Description string
+	Created     time.Time
+	// Admin reports whether the API token owns the project. It is served as
+	// the owner field of project/list.
+	Admin bool
+	// Role is the role served for the API token. If empty it is derived from
+	// Admin.
+	Role lokalise.Role
+	// BaseLanguage is the ISO code of the base language. If empty the first
+	// of Languages is used.
+	BaseLanguage string
+	// Languages holds the ISO codes of the project languages.
+	Languages []string
+	// LanguageNames maps ISO codes to language names. Languages without a
+	// name are named after their code.
+	LanguageNames map[string]string
+	Keys          []Key
+	Contributors  []Contributor
+	Screenshots   []Screenshot
+	Tasks         []Task
+}
+
+// Task is a translation task of a Project.
+type Task struct {
+	// ID is assigned by the Server if zero.
+	ID          int64
+	Title       string
+	Description string
+	// Status defaults to lokalise.TaskInProgress.
+	Status lokalise.TaskStatus
+	KeyIDs []int64
+	// Languages maps the ISO codes of the task languages to the user IDs of
+	// the assigned contributors.
+	Languages map[string][]int64
+	DueDate   time.Time
+	Created   time.Time
+}
+
+// Screenshot is an image uploaded to a Project.
+type Screenshot struct {
+	// ID is assigned by the Server if zero.
+	ID          int64
+	Title       string
+	Description string
+	Tags        []string
+	// KeyIDs holds the IDs of the linked keys.
+	KeyIDs []int64
+	// Filename and Content hold the uploaded image.
+	Filename string
+	Content  []byte
+	Created  time.Time
+}
+
+// Contributor is a user working on a Project.
+type Contributor struct {
+	// ID is assigned by the Server if zero.
+	ID       int64
+	Email    string
+	Name     string
+	Admin    bool
+	Reviewer bool
+	// Languages maps the ISO codes of accessible languages to whether the
+	// contributor may write to them.
+	Languages map[string]bool
+}
+
+// Key is a translation key of a Project.
+type Key struct {
+	// ID is assigned by the Server if zero.
+	ID          int64
+	Name        string
+	Description string
+	Context     string
+	Platforms   []string
+	Tags        []string
+	Filename    string
+	// Plural marks a plural key, whose translations hold the plural forms
+	// as a JSON object.
+	Plural bool
+	// Translations maps language ISO codes to translations.
+	Translations map[string]string
+	// Statuses maps language ISO codes to the status of the translation.
+	Statuses map[string]Status
+}
+
+// Status is the review status of a translation.
+type Status struct {
+	Reviewed   bool
+	Unverified bool
+	Fuzzy      bool
+}
+
+// details returns the project as served by project/list and project/get.
+func (p *Project) details() map[string]interface{} {
+	owner := "0"
+	if p.Admin {
+		owner = "1"
+	}
+	role := p.Role
+	if role == "" {
+		role = lokalise.RoleContributor
+		if p.Admin {
+			role = lokalise.RoleAdmin
+		}
+	}
+	base := p.BaseLanguage
+	if base == "" && len(p.Languages) > 0 {
+		base = p.Languages[0]
+	}
+	languages := []map[string]interface{}{}
+	for _, iso := range p.Languages {
+		languages = append(languages, p.language(iso))
+	}
+	var words int
+	for _, k := range p.Keys {
+		words += len(strings.Fields(k.Translations[base]))
+	}
+	return map[string]interface{}{
+		"id":            p.ID,
+		"name":          p.Name,
+		"desc":          p.Description,
+		"created":       p.Created.Format("2006-01-02 15:04:05"),
+		"owner":         owner,
+		"role":          role,
+		"base_lang_iso": base,
+		"languages":     languages,
+		"keys":          len(p.Keys),
+		"words":         words,
+	}
+}
+
+func (p *Project) hasLanguage(iso string) bool {
+	for _, l := range p.Languages {
+		if l == iso {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Project) key(name string) *Key {
+	for i := range p.Keys {
+		if p.Keys[i].Name == name {
+			return &p.Keys[i]
+		}
+	}
+	return nil
+}
+
+func (k *Key) hasAnyTag(tags []string) bool {
+	for _, t := range tags {
+		for _, kt := range k.Tags {
+			if t == kt {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (k *Key) addTags(tags []string) {
+	for _, t := range tags {
+		if !k.hasAnyTag([]string{t}) {
+			k.Tags = append(k.Tags, t)
+		}
+	}
+}
+
+func copyProject(p Project) Project {
+	p.Languages = append([]string(nil), p.Languages...)
+	names := make(map[string]string, len(p.LanguageNames))
+	for iso, name := range p.LanguageNames {
+		names[iso] = name
+	}
+	p.LanguageNames = names
+	contributors := make([]Contributor, len(p.Contributors))
+	for i, c := range p.Contributors {
+		languages := make(map[string]bool, len(c.Languages))
+		for iso, writable := range c.Languages {
+			languages[iso] = writable
+		}
+		c.Languages = languages
+		contributors[i] = c
+	}
+	p.Contributors = contributors
+	screenshots := make([]Screenshot, len(p.Screenshots))
+	for i, shot := range p.Screenshots {
+		shot.Tags = append([]string(nil), shot.Tags...)
+		shot.KeyIDs = append([]int64(nil), shot.KeyIDs...)
+		screenshots[i] = shot
+	}
+	p.Screenshots = screenshots
+	tasks := make([]Task, len(p.Tasks))
+	for i, t := range p.Tasks {
+		t.KeyIDs = append([]int64(nil), t.KeyIDs...)
+		languages := make(map[string][]int64, len(t.Languages))
+		for iso, users := range t.Languages {
+			languages[iso] = append([]int64(nil), users...)
+		}
+		t.Languages = languages
+		tasks[i] = t
+	}
+	p.Tasks = tasks
+	keys := make([]Key, len(p.Keys))
+	for i, k := range p.Keys {
+		k.Tags = append([]string(nil), k.Tags...)
+		k.Platforms = append([]string(nil), k.Platforms...)
+		translations := make(map[string]string, len(k.Translations))
+		for iso, t := range k.Translations {
+			translations[iso] = t
+		}
+		k.Translations = translations
+		statuses := make(map[string]Status, len(k.Statuses))
+		for iso, status := range k.Statuses {
+			statuses[iso] = status
+		}
+		k.Statuses = statuses
+		keys[i] = k
+	}
+	p.Keys = keys
+	return p
+}
+
+type exportRequest struct {
+	fileType        string
+	languages       []string
+	includeTags     []string
+	excludeTags     []string
+	bundleStructure string
+}
+
+// bundle renders a zip bundle with one file per language. Files of type
+// "json" hold a flat JSON object, all other types hold "key=value" lines.
+func (p *Project) bundle(req exportRequest) ([]byte, error) {
+	languages := req.languages
+	if len(languages) == 0 {
+		languages = p.Languages
+	}
+	structure := req.bundleStructure
+	if structure == "" {
+		structure = "%LANG_ISO%.%FORMAT%"
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, iso := range languages {
+		translations := map[string]string{}
+		for i := range p.Keys {
+			k := &p.Keys[i]
+			if len(req.includeTags) > 0 && !k.hasAnyTag(req.includeTags) {
+				continue
+			}
+			if k.hasAnyTag(req.excludeTags) {
+				continue
+			}
+			translations[k.Name] = k.Translations[iso]
+		}
+		name := strings.NewReplacer(
+			"%LANG_ISO%", iso,
+			"%LANG_NAME%", iso,
+			"%FORMAT%", req.fileType,
+			"%PROJECT_NAME%", p.Name,
+		).Replace(structure)
+		w, err := zw.Create(path.Clean(name))
+		if err != nil {
+			return nil, err
+		}
+		content, err := encode(req.fileType, translations)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encode(fileType string, translations map[string]string) ([]byte, error) {
+	if fileType == "json" {
+		return json.MarshalIndent(translations, "", "  ")
+	}
+	names := make([]string, 0, len(translations))
+	for name := range translations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var buf bytes.Buffer
+	for _, name := range names {
+		fmt.Fprintf(&buf, "%s=%s\n", name, translations[name])
+	}
+	return buf.Bytes(), nil
+}
+
+// decode parses an uploaded file. Files named *.json must hold a flat JSON
+// object, all other files "key=value" lines.
+func decode(filename string, content []byte) (map[string]string, error) {
+	translations := map[string]string{}
+	if strings.HasSuffix(filename, ".json") {
+		if err := json.Unmarshal(content, &translations); err != nil {
+			return nil, err
+		}
+		return translations, nil
+	}
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.Index(line, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("line %q is not a key=value pair", line)
+		}
+		translations[strings.TrimSpace(line[:i])] = strings.TrimSpace(line[i+1:])
+	}
+	return translations, s.Err()
+}
@@ -0,0 +1,283 @@
+package lokalisetest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Mode selects whether a Recorder records or replays interactions.
+type Mode int
+
+const (
+	// ModeReplay serves responses from a cassette without network access.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the real transport and records them.
+	ModeRecord
+)
+
+const redacted = "[REDACTED]"
+
+// Interaction is a recorded request and response pair.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of a request that interactions are matched on.
+// The API token is redacted.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	// Path is the URL path, e.g. "/api/project/export".
+	Path string `json:"path"`
+	// Form holds the decoded query and body fields. An uploaded file is
+	// represented by its filename and the SHA-256 digest of its content.
+	Form url.Values `json:"form,omitempty"`
+}
+
+// RecordedResponse is a recorded response. The API token is redacted from
+// its header and body.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       []byte      `json:"body,omitempty"`
+}
+
+// Recorder is a cassette-style http.RoundTripper. In ModeRecord it passes
+// requests to a transport and records them; Save writes them to the cassette
+// file. In ModeReplay it answers requests from the cassette file instead.
+//
+// Requests are matched on method, URL path and decoded form fields, so the
+// random boundaries of multipart uploads do not matter. Recorded interactions
+// are replayed in order; once all matches are used the last one is repeated.
+//
+// Use it with lokalise.WithTransport:
+//
+//  rec, err := lokalisetest.NewRecorder("testdata/export.json", lokalisetest.ModeReplay, nil)
+//  client, err := lokalise.NewClient(lokalise.WithTransport(rec), lokalise.WithAPIToken(token))
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+type cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// NewRecorder returns a Recorder for the cassette file at path. In ModeReplay
+// the cassette is loaded immediately. In ModeRecord requests are sent with
+// transport, or http.DefaultTransport if nil.
+func NewRecorder(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: transport,
+	}
+	if r.transport == nil {
+		r.transport = http.DefaultTransport
+	}
+	if mode == ModeReplay {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var c cassette
+		if err := json.Unmarshal(content, &c); err != nil {
+			return nil, fmt.Errorf("lokalisetest: reading cassette %s: %w", path, err)
+		}
+		r.interactions = c.Interactions
+		r.used = make([]bool, len(c.Interactions))
+	}
+	return r, nil
+}
+
+// Interactions returns the interactions recorded or loaded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save writes the recorded interactions to the cassette file. It is a no-op
+// in ModeReplay.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	content, err := json.MarshalIndent(cassette{Interactions: r.interactions}, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, content, 0644)
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, token, body, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+	resp, err := r.transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	header := resp.Header.Clone()
+	if token != "" {
+		respBody = bytes.Replace(respBody, []byte(token), []byte(redacted), -1)
+		for _, values := range header {
+			for i, v := range values {
+				values[i] = strings.Replace(v, token, redacted, -1)
+			}
+		}
+	}
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       respBody,
+		},
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	match := -1
+	for i, in := range r.interactions {
+		if !in.Request.matches(recorded) {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("lokalisetest: no recorded interaction for %s %s", recorded.Method, recorded.Path)
+	}
+	r.used[match] = true
+	rr := r.interactions[match].Response
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rr.StatusCode, http.StatusText(rr.StatusCode)),
+		StatusCode:    rr.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rr.Header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(rr.Body)),
+		ContentLength: int64(len(rr.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (rr RecordedRequest) matches(other RecordedRequest) bool {
+	if rr.Method != other.Method || rr.Path != other.Path {
+		return false
+	}
+	if len(rr.Form) == 0 && len(other.Form) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(rr.Form, other.Form)
+}
+
+// recordRequest decodes req into a RecordedRequest with the API token
+// redacted. It returns the token and the raw body, which the caller must use
+// in place of req.Body.
+func recordRequest(req *http.Request) (RecordedRequest, string, []byte, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return RecordedRequest{}, "", nil, err
+		}
+	}
+
+	form := url.Values{}
+	for k, v := range req.URL.Query() {
+		form[k] = v
+	}
+	mediaType, params, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return RecordedRequest{}, "", nil, err
+		}
+		for k, v := range values {
+			form[k] = append(form[k], v...)
+		}
+	case "multipart/form-data":
+		mr := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return RecordedRequest{}, "", nil, err
+			}
+			content, err := ioutil.ReadAll(part)
+			if err != nil {
+				return RecordedRequest{}, "", nil, err
+			}
+			if part.FileName() != "" {
+				sum := sha256.Sum256(content)
+				form.Add(part.FormName(), part.FileName()+" sha256:"+hex.EncodeToString(sum[:]))
+				continue
+			}
+			form.Add(part.FormName(), string(content))
+		}
+	}
+
+	token := form.Get("api_token")
+	if _, ok := form["api_token"]; ok {
+		form["api_token"] = []string{redacted}
+	}
+	if len(form) == 0 {
+		form = nil
+	}
+	return RecordedRequest{
+		Method: req.Method,
+		Path:   strings.TrimSuffix(req.URL.Path, "/"),
+		Form:   form,
+	}, token, body, nil
+}
@@ -0,0 +1,157 @@
+package lokalisetest_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+	"github.com/lokalise/lokalise-cli-go/lokalise/lokalisetest"
+)
+
+// newCassette returns the path of a cassette in a new temporary directory
+// along with a function removing the directory.
+func newCassette(t *testing.T) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "lokalisetest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, "cassette.json"), func() { os.RemoveAll(dir) }
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+	path, cleanup := newCassette(t)
+	defer cleanup()
+	srv := newServer(t)
+	defer srv.Close()
+	ctx := context.Background()
+	content := []byte(`{"greeting":"Hi"}`)
+
+	rec, err := lokalisetest.NewRecorder(path, lokalisetest.ModeRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newClient(t, srv, lokalise.WithTransport(rec))
+	projects, err := c.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := c.ImportBytes(ctx, projectID, "en.json", content, "en", lokalise.WithReplace(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(rec.Interactions()); n != 2 {
+		t.Fatalf("recorded %d interactions, want 2", n)
+	}
+
+	// Replaying needs no server.
+	srv.Close()
+	rec, err = lokalisetest.NewRecorder(path, lokalisetest.ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c = newClient(t, srv, lokalise.WithTransport(rec))
+	replayed, err := c.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(replayed, projects) {
+		t.Errorf("replayed projects %+v, want %+v", replayed, projects)
+	}
+	replayedResult, err := c.ImportBytes(ctx, projectID, "en.json", content, "en", lokalise.WithReplace(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayedResult != result {
+		t.Errorf("replayed result %+v, want %+v", replayedResult, result)
+	}
+}
+
+// echoTransport sends requests with http.DefaultTransport and adds the token
+// to the headers of the responses.
+type echoTransport struct {
+	token string
+}
+
+func (rt echoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err == nil {
+		resp.Header.Set("X-Echo", "token="+rt.token)
+	}
+	return resp, err
+}
+
+func TestRecorderRedactsToken(t *testing.T) {
+	path, cleanup := newCassette(t)
+	defer cleanup()
+	srv := newServer(t)
+	defer srv.Close()
+	// The server echoes the token in a response header.
+	rec, err := lokalisetest.NewRecorder(path, lokalisetest.ModeRecord, echoTransport{srv.Token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newClient(t, srv, lokalise.WithTransport(rec))
+	if _, err := c.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, in := range rec.Interactions() {
+		if got := in.Request.Form.Get("api_token"); got != "[REDACTED]" {
+			t.Errorf("recorded api_token %q, want it redacted", got)
+		}
+		if got := in.Response.Header.Get("X-Echo"); got != "token=[REDACTED]" {
+			t.Errorf("recorded header X-Echo %q, want the token redacted", got)
+		}
+	}
+	cassette, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(cassette, []byte(srv.Token)) {
+		t.Errorf("cassette contains the token:\n%s", cassette)
+	}
+}
+
+func TestRecorderNoMatch(t *testing.T) {
+	path, cleanup := newCassette(t)
+	defer cleanup()
+	srv := newServer(t)
+	defer srv.Close()
+
+	rec, err := lokalisetest.NewRecorder(path, lokalisetest.ModeRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newClient(t, srv, lokalise.WithTransport(rec))
+	if _, err := c.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err = lokalisetest.NewRecorder(path, lokalisetest.ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c = newClient(t, srv, lokalise.WithTransport(rec))
+	if _, err := c.Export(context.Background(), projectID, "json"); err == nil {
+		t.Error("got no error for a request missing from the cassette")
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("got %d requests, want only the recorded one", n)
+	}
+}
@@ -0,0 +1,94 @@
+package lokalisetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+// details returns the screenshot as served by the API, with the image
+// served from assetURL.
+func (shot *Screenshot) details(assetURL string) map[string]interface{} {
+	return map[string]interface{}{
+		"screenshot_id": shot.ID,
+		"title":         shot.Title,
+		"description":   shot.Description,
+		"tags":          append([]string{}, shot.Tags...),
+		"key_ids":       append([]int64{}, shot.KeyIDs...),
+		"url":           assetURL + shot.asset(),
+		"created":       shot.Created.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func (shot *Screenshot) asset() string {
+	return fmt.Sprintf("screenshots/%d/%s", shot.ID, shot.Filename)
+}
+
+func (s *Server) screenshots(w http.ResponseWriter, endpoint string, req Request) {
+	p := s.project(req.Form.Get("id"))
+	if p == nil {
+		writeError(w, lokalise.AccessDenied, "Project not found")
+		return
+	}
+	switch endpoint {
+	case "screenshot/list":
+		screenshots := []map[string]interface{}{}
+		for i := range p.Screenshots {
+			screenshots = append(screenshots, p.Screenshots[i].details(s.AssetURL))
+		}
+		writeJSON(w, map[string]interface{}{"screenshots": screenshots})
+	case "screenshot/delete":
+		id, _ := strconv.ParseInt(req.Form.Get("screenshot_id"), 10, 64)
+		for i := range p.Screenshots {
+			if p.Screenshots[i].ID == id {
+				delete(s.assets, p.Screenshots[i].asset())
+				p.Screenshots = append(p.Screenshots[:i], p.Screenshots[i+1:]...)
+				writeJSON(w, map[string]interface{}{})
+				return
+			}
+		}
+		writeError(w, lokalise.Custom, fmt.Sprintf("Screenshot %d not found", id))
+	case "screenshot/upload":
+		if req.File == nil {
+			writeError(w, lokalise.NoData, "No file uploaded")
+			return
+		}
+		shot := Screenshot{
+			ID:          s.nextID(),
+			Title:       req.Form.Get("title"),
+			Description: req.Form.Get("description"),
+			Filename:    req.Filename,
+			Content:     req.File,
+			Created:     time.Now().UTC(),
+		}
+		if shot.Title == "" {
+			shot.Title = strings.TrimSuffix(req.Filename, path.Ext(req.Filename))
+		}
+		for field, dst := range map[string]interface{}{
+			"tags":    &shot.Tags,
+			"key_ids": &shot.KeyIDs,
+		} {
+			if v := req.Form.Get(field); v != "" {
+				if err := json.Unmarshal([]byte(v), dst); err != nil {
+					writeError(w, lokalise.NotJSON, fmt.Sprintf("Invalid %s", field))
+					return
+				}
+			}
+		}
+		for _, id := range shot.KeyIDs {
+			if p.keyByID(id) == nil {
+				writeError(w, lokalise.Custom, fmt.Sprintf("Key %d not found", id))
+				return
+			}
+		}
+		p.Screenshots = append(p.Screenshots, shot)
+		s.assets[shot.asset()] = shot.Content
+		writeJSON(w, map[string]interface{}{"screenshot": shot.details(s.AssetURL)})
+	}
+}
@@ -0,0 +1,443 @@
+// Package lokalisetest provides an in-process fake of the Lokalise web API
+// for testing code that uses package lokalise.
+//
+// The fake implements the project, language, key, translation, snapshot,
+// contributor, screenshot and task endpoints on top of in-memory projects
+// and serves real zip bundles from a fake asset URL:
+//
+//  srv := lokalisetest.NewServer()
+//  defer srv.Close()
+//  srv.AddProject(lokalisetest.Project{ID: "123.abc", Name: "App", Languages: []string{"en"}})
+//
+//  client, err := srv.Client()
+//  // use client, or set lokalise.DefaultClient = client for the package level functions
+package lokalisetest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+// Token is the API token accepted by a Server unless changed.
+const Token = "lokalisetest-token"
+
+// Request is an API request received by a Server.
+type Request struct {
+	// Endpoint is the API endpoint, e.g. "project/export".
+	Endpoint string
+	// Form holds the decoded form fields, including the API token.
+	Form url.Values
+	// Filename and File hold the uploaded file of an import.
+	Filename string
+	File     []byte
+}
+
+// Server is a fake Lokalise API. It is safe for concurrent use.
+type Server struct {
+	// URL is the API base URL, for use with lokalise.WithBaseURL.
+	URL string
+	// AssetURL is the URL bundles are served from, for use with lokalise.WithAssetURL.
+	AssetURL string
+	// Token is the API token the Server accepts.
+	Token string
+
+	srv      *httptest.Server
+	mu       sync.Mutex
+	projects []*Project
+	faults   map[string][]fault
+	requests []Request
+	assets   map[string][]byte
+	snaps    map[string][]snapshot
+	lastID   int64
+}
+
+type fault struct {
+	status  int
+	code    lokalise.Code
+	message string
+}
+
+// NewServer starts and returns a new Server. The caller should call Close
+// when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		Token:  Token,
+		faults: map[string][]fault{},
+		assets: map[string][]byte{},
+		snaps:  map[string][]snapshot{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/", s.serveAPI)
+	mux.HandleFunc("/assets/", s.serveAsset)
+	s.srv = httptest.NewServer(mux)
+	s.URL = s.srv.URL + "/api/"
+	s.AssetURL = s.srv.URL + "/assets/"
+	return s
+}
+
+// Close shuts down the Server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns a lokalise.Client talking to the Server with its Token.
+// Retries are disabled so that injected errors surface immediately; opts are
+// applied last and may override this.
+func (s *Server) Client(opts ...lokalise.ClientOption) (*lokalise.Client, error) {
+	base := []lokalise.ClientOption{
+		lokalise.WithBaseURL(s.URL),
+		lokalise.WithAssetURL(s.AssetURL),
+		lokalise.WithAPIToken(s.Token),
+		lokalise.WithHTTPClient(s.srv.Client()),
+		lokalise.WithRetryPolicy(lokalise.RetryPolicy{MaxAttempts: 1}),
+	}
+	return lokalise.NewClient(append(base, opts...)...)
+}
+
+// AddProject adds or replaces the project with the ID of p.
+func (s *Server) AddProject(p Project) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp := copyProject(p)
+	for i := range cp.Keys {
+		if cp.Keys[i].ID == 0 {
+			cp.Keys[i].ID = s.nextID()
+		}
+	}
+	for i := range cp.Contributors {
+		if cp.Contributors[i].ID == 0 {
+			cp.Contributors[i].ID = s.nextID()
+		}
+	}
+	for i := range cp.Screenshots {
+		if cp.Screenshots[i].ID == 0 {
+			cp.Screenshots[i].ID = s.nextID()
+		}
+		s.assets[cp.Screenshots[i].asset()] = cp.Screenshots[i].Content
+	}
+	for i := range cp.Tasks {
+		if cp.Tasks[i].ID == 0 {
+			cp.Tasks[i].ID = s.nextID()
+		}
+	}
+	for i, existing := range s.projects {
+		if existing.ID == p.ID {
+			s.projects[i] = &cp
+			return
+		}
+	}
+	s.projects = append(s.projects, &cp)
+}
+
+// Project returns a copy of the project with ID id.
+func (s *Server) Project(id string) (Project, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.project(id)
+	if p == nil {
+		return Project{}, false
+	}
+	return copyProject(*p), true
+}
+
+// FailNext makes the next request to endpoint fail with an API error of the
+// given code, e.g. lokalise.RateLimit. Calls queue up in order.
+func (s *Server) FailNext(endpoint string, code lokalise.Code, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[endpoint] = append(s.faults[endpoint], fault{code: code, message: message})
+}
+
+// FailNextStatus makes the next request to endpoint fail with the HTTP
+// status code status. Calls queue up in order.
+func (s *Server) FailNextStatus(endpoint string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[endpoint] = append(s.faults[endpoint], fault{status: status})
+}
+
+// Requests returns the requests received so far, oldest first.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// nextID returns a new ID for keys and other resources.
+func (s *Server) nextID() int64 {
+	s.lastID++
+	return s.lastID
+}
+
+func (s *Server) project(id string) *Project {
+	for _, p := range s.projects {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *Server) serveAsset(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	content, ok := s.assets[strings.TrimPrefix(r.URL.Path, "/assets/")]
+	s.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	// The ETag lets clients resume downloads with Range and If-Range.
+	w.Header().Set("Content-Type", http.DetectContentType(content))
+	w.Header().Set("ETag", fmt.Sprintf(`"%x"`, sha256.Sum256(content)))
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+}
+
+func (s *Server) serveAPI(w http.ResponseWriter, r *http.Request) {
+	endpoint := strings.TrimPrefix(r.URL.Path, "/api/")
+	req := Request{Endpoint: endpoint}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if f, h, err := r.FormFile("file"); err == nil {
+			req.Filename = h.Filename
+			req.File, _ = ioutil.ReadAll(f)
+			f.Close()
+		}
+	} else if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.Form = url.Values{}
+	for k, v := range r.Form {
+		req.Form[k] = append([]string(nil), v...)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, req)
+
+	if faults := s.faults[endpoint]; len(faults) > 0 {
+		s.faults[endpoint] = faults[1:]
+		f := faults[0]
+		if f.status != 0 {
+			http.Error(w, http.StatusText(f.status), f.status)
+			return
+		}
+		writeError(w, f.code, f.message)
+		return
+	}
+
+	switch token := req.Form.Get("api_token"); {
+	case token == "":
+		writeError(w, lokalise.MissingAPIToken, "Missing API token")
+		return
+	case token != s.Token:
+		writeError(w, lokalise.InvalidAPIToken, "Invalid API token")
+		return
+	}
+
+	switch endpoint {
+	case "project/list":
+		s.list(w)
+	case "project/get":
+		s.get(w, req)
+	case "project/export":
+		s.export(w, req)
+	case "project/import":
+		s.importFile(w, req)
+	case "language/list", "language/add", "language/update", "language/remove":
+		s.languages(w, endpoint, req)
+	case "key/list":
+		s.listKeys(w, req)
+	case "key/create", "key/update", "key/delete":
+		s.changeKeys(w, endpoint, req)
+	case "translation/list":
+		s.listTranslations(w, req)
+	case "translation/update":
+		s.updateTranslation(w, req)
+	case "snapshot/create", "snapshot/list", "snapshot/restore":
+		s.snapshots(w, endpoint, req)
+	case "contributor/list", "contributor/add", "contributor/update", "contributor/remove":
+		s.contributors(w, endpoint, req)
+	case "screenshot/upload", "screenshot/list", "screenshot/delete":
+		s.screenshots(w, endpoint, req)
+	case "task/create", "task/list", "task/close":
+		s.tasks(w, endpoint, req)
+	default:
+		writeError(w, lokalise.InvalidCall, "Invalid API call")
+	}
+}
+
+func (s *Server) list(w http.ResponseWriter) {
+	projects := []map[string]interface{}{}
+	for _, p := range s.projects {
+		projects = append(projects, p.details())
+	}
+	writeJSON(w, map[string]interface{}{"projects": projects})
+}
+
+func (s *Server) get(w http.ResponseWriter, req Request) {
+	p := s.project(req.Form.Get("id"))
+	if p == nil {
+		writeError(w, lokalise.AccessDenied, "Project not found")
+		return
+	}
+	writeJSON(w, map[string]interface{}{"project": p.details()})
+}
+
+func (s *Server) export(w http.ResponseWriter, req Request) {
+	p := s.project(req.Form.Get("id"))
+	if p == nil {
+		writeError(w, lokalise.AccessDenied, "Project not found")
+		return
+	}
+	fileType := req.Form.Get("type")
+	if fileType == "" {
+		writeError(w, lokalise.MissingRequestParameter, "Missing type")
+		return
+	}
+	er := exportRequest{
+		fileType:        fileType,
+		bundleStructure: req.Form.Get("bundle_structure"),
+	}
+	for field, dst := range map[string]*[]string{
+		"langs":        &er.languages,
+		"include_tags": &er.includeTags,
+		"exclude_tags": &er.excludeTags,
+	} {
+		if v := req.Form.Get(field); v != "" {
+			if err := json.Unmarshal([]byte(v), dst); err != nil {
+				writeError(w, lokalise.Custom, fmt.Sprintf("Invalid %s", field))
+				return
+			}
+		}
+	}
+	for _, iso := range er.languages {
+		if !p.hasLanguage(iso) {
+			writeError(w, lokalise.LanguageNotAvailable, fmt.Sprintf("Language %s is not available", iso))
+			return
+		}
+	}
+	content, err := p.bundle(er)
+	if err != nil {
+		writeError(w, lokalise.Custom, err.Error())
+		return
+	}
+	file := fmt.Sprintf("export/%s_%d.zip", strings.Replace(p.Name, " ", "_", -1), len(s.assets)+1)
+	s.assets[file] = content
+
+	bundle := map[string]string{"file": file}
+	if req.Form.Get("webhook_url") == "" {
+		bundle["full_file"] = s.AssetURL + file
+	}
+	writeJSON(w, map[string]interface{}{"bundle": bundle})
+}
+
+func (s *Server) importFile(w http.ResponseWriter, req Request) {
+	p := s.project(req.Form.Get("id"))
+	if p == nil {
+		writeError(w, lokalise.AccessDenied, "Project not found")
+		return
+	}
+	iso := req.Form.Get("lang_iso")
+	if iso == "" {
+		writeError(w, lokalise.LanguageNotSpecified, "Language not specified")
+		return
+	}
+	if !p.hasLanguage(iso) {
+		writeError(w, lokalise.LanguageNotAvailable, fmt.Sprintf("Language %s is not available", iso))
+		return
+	}
+	if req.File == nil {
+		writeError(w, lokalise.NoData, "No file uploaded")
+		return
+	}
+	translations, err := decode(req.Filename, req.File)
+	if err != nil {
+		writeError(w, lokalise.InvalidFile, err.Error())
+		return
+	}
+	var tags, inserted, updated, skipped []string
+	for field, dst := range map[string]*[]string{
+		"tags":              &tags,
+		"tag_inserted_keys": &inserted,
+		"tag_updated_keys":  &updated,
+		"tag_skipped_keys":  &skipped,
+	} {
+		if v := req.Form.Get(field); v != "" {
+			json.Unmarshal([]byte(v), dst)
+		}
+	}
+	replace := req.Form.Get("replace") == "1"
+
+	var result struct {
+		Skipped  int64 `json:"skipped"`
+		Inserted int64 `json:"inserted"`
+		Updated  int64 `json:"updated"`
+	}
+	// Insert new keys in name order, so that key IDs are deterministic.
+	names := make([]string, 0, len(translations))
+	for name := range translations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		translation := translations[name]
+		k := p.key(name)
+		switch {
+		case k == nil:
+			p.Keys = append(p.Keys, Key{ID: s.nextID(), Name: name, Translations: map[string]string{iso: translation}})
+			k = &p.Keys[len(p.Keys)-1]
+			k.addTags(tags)
+			k.addTags(inserted)
+			result.Inserted++
+		case k.Translations[iso] == "" || (replace && k.Translations[iso] != translation):
+			if k.Translations == nil {
+				k.Translations = map[string]string{}
+			}
+			k.Translations[iso] = translation
+			k.addTags(tags)
+			k.addTags(updated)
+			result.Updated++
+		default:
+			k.addTags(skipped)
+			result.Skipped++
+		}
+	}
+	writeJSON(w, map[string]interface{}{"result": result})
+}
+
+func writeJSON(w http.ResponseWriter, v map[string]interface{}) {
+	v["response"] = map[string]string{
+		"status":  "success",
+		"code":    string(lokalise.OK),
+		"message": "OK",
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code lokalise.Code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"response": map[string]string{
+			"status":  "error",
+			"code":    string(code),
+			"message": message,
+		},
+	})
+}
@@ -0,0 +1,304 @@
+package lokalisetest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+	"github.com/lokalise/lokalise-cli-go/lokalise/lokalisetest"
+)
+
+const projectID = "123.abc"
+
+// newServer returns a Server with a single project with ID projectID.
+func newServer(t *testing.T) *lokalisetest.Server {
+	t.Helper()
+	srv := lokalisetest.NewServer()
+	srv.AddProject(lokalisetest.Project{
+		ID:        projectID,
+		Name:      "App",
+		Admin:     true,
+		Languages: []string{"en", "de"},
+		Keys: []lokalisetest.Key{
+			{Name: "greeting", Tags: []string{"web"}, Translations: map[string]string{"en": "Hello", "de": "Hallo"}},
+			{Name: "farewell", Tags: []string{"ios"}, Translations: map[string]string{"en": "Bye", "de": "Tschüss"}},
+		},
+	})
+	return srv
+}
+
+func newClient(t *testing.T, srv *lokalisetest.Server, opts ...lokalise.ClientOption) *lokalise.Client {
+	t.Helper()
+	c, err := srv.Client(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// translations returns the translations of the keys of the project with ID
+// projectID in language iso.
+func translations(t *testing.T, srv *lokalisetest.Server, iso string) map[string]string {
+	t.Helper()
+	p, ok := srv.Project(projectID)
+	if !ok {
+		t.Fatalf("project %s not found", projectID)
+	}
+	m := map[string]string{}
+	for _, k := range p.Keys {
+		m[k.Name] = k.Translations[iso]
+	}
+	return m
+}
+
+func TestList(t *testing.T) {
+	srv := newServer(t)
+	defer srv.Close()
+	c := newClient(t, srv)
+
+	projects, err := c.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 1 || projects[0].ID != projectID || projects[0].Name != "App" || projects[0].Owner != "1" {
+		t.Errorf("projects = %+v, want project %s owned by the token", projects, projectID)
+	}
+}
+
+func TestImport(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []lokalise.ImportOption
+		want    lokalise.ImportResult
+		greeted string
+	}{
+		{"keep", nil, lokalise.ImportResult{Inserted: 1, Skipped: 1}, "Hello"},
+		{"replace", []lokalise.ImportOption{lokalise.WithReplace(true)}, lokalise.ImportResult{Inserted: 1, Updated: 1}, "Hi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newServer(t)
+			defer srv.Close()
+			c := newClient(t, srv)
+
+			content := []byte(`{"greeting":"Hi","welcome":"Welcome"}`)
+			result, err := c.ImportBytes(context.Background(), projectID, "en.json", content, "en", tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result != tt.want {
+				t.Errorf("result = %+v, want %+v", result, tt.want)
+			}
+			got := translations(t, srv, "en")
+			if got["greeting"] != tt.greeted || got["welcome"] != "Welcome" || got["farewell"] != "Bye" {
+				t.Errorf("translations = %v", got)
+			}
+		})
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	srv := newServer(t)
+	defer srv.Close()
+	c := newClient(t, srv)
+	ctx := context.Background()
+
+	if _, err := c.ImportBytes(ctx, "999.zzz", "en.json", []byte(`{}`), "en"); !errors.Is(err, lokalise.ErrAccessDenied) {
+		t.Errorf("unknown project: error = %v, want ErrAccessDenied", err)
+	}
+	if _, err := c.ImportBytes(ctx, projectID, "fr.json", []byte(`{}`), "fr"); !errors.Is(err, lokalise.ErrLanguageNotAvailable) {
+		t.Errorf("unknown language: error = %v, want ErrLanguageNotAvailable", err)
+	}
+	if _, err := c.ImportBytes(ctx, projectID, "en.json", []byte(`not json`), "en"); !errors.Is(err, lokalise.ErrInvalidFile) {
+		t.Errorf("invalid file: error = %v, want ErrInvalidFile", err)
+	}
+
+	bad, err := srv.Client(lokalise.WithAPIToken("wrong"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bad.List(ctx); !errors.Is(err, lokalise.ErrInvalidAPIToken) {
+		t.Errorf("wrong token: error = %v, want ErrInvalidAPIToken", err)
+	}
+}
+
+func TestExportDownloadExtract(t *testing.T) {
+	srv := newServer(t)
+	defer srv.Close()
+	c := newClient(t, srv)
+	ctx := context.Background()
+
+	b, err := c.Export(ctx, projectID, "json",
+		lokalise.WithLanguages("de"),
+		lokalise.WithBundleStructure("locale/%LANG_ISO%.%FORMAT%"),
+		lokalise.WithIncludeTags("web"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err := c.DownloadBundle(ctx, b, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "lokalisetest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files, err := lokalise.ExtractBundle(bytes.NewReader(buf.Bytes()), int64(buf.Len()), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Name != "locale/de.json" {
+		t.Fatalf("files = %+v, want locale/de.json", files)
+	}
+	content, err := ioutil.ReadFile(filepath.Join(dir, "locale", "de.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]string
+	if err := json.Unmarshal(content, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got["greeting"] != "Hallo" {
+		t.Errorf("bundle holds %v, want the German translation of the web key", got)
+	}
+}
+
+func TestExportWebhook(t *testing.T) {
+	srv := newServer(t)
+	defer srv.Close()
+	c := newClient(t, srv)
+
+	b, err := c.Export(context.Background(), projectID, "json", lokalise.WithWebhookURL("https://example.com/hook"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The client resolves the bundle against the asset URL of the fake.
+	if b.File == "" || b.FullFile != srv.AssetURL+b.File {
+		t.Errorf("bundle = %+v, want the file below %s", b, srv.AssetURL)
+	}
+	if _, err := c.DownloadBundle(context.Background(), b, ioutil.Discard); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFailNext(t *testing.T) {
+	srv := newServer(t)
+	defer srv.Close()
+	c := newClient(t, srv)
+	ctx := context.Background()
+
+	srv.FailNext("project/list", lokalise.RateLimit, "Too many requests")
+	srv.FailNextStatus("project/list", http.StatusBadGateway)
+	if _, err := c.List(ctx); !errors.Is(err, lokalise.ErrRateLimit) {
+		t.Errorf("first call: error = %v, want ErrRateLimit", err)
+	}
+	var httpErr *lokalise.HTTPError
+	if _, err := c.List(ctx); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("second call: error = %v, want status 502", err)
+	}
+	if _, err := c.List(ctx); err != nil {
+		t.Errorf("third call: %v", err)
+	}
+
+	// Faults are retried like real API errors.
+	retrying := newClient(t, srv, lokalise.WithRetryPolicy(lokalise.RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+	}))
+	srv.FailNextStatus("project/list", http.StatusServiceUnavailable)
+	if _, err := retrying.List(ctx); err != nil {
+		t.Error(err)
+	}
+
+	reqs := srv.Requests()
+	if len(reqs) != 5 {
+		t.Fatalf("got %d requests, want 5", len(reqs))
+	}
+	for _, req := range reqs {
+		if req.Endpoint != "project/list" || req.Form.Get("api_token") != srv.Token {
+			t.Errorf("request %+v, want project/list with the token", req)
+		}
+	}
+}
+
+func TestImportOrder(t *testing.T) {
+	srv := newServer(t)
+	defer srv.Close()
+	c := newClient(t, srv)
+
+	content := []byte(`{"zebra":"Zebra","apple":"Apple","mango":"Mango"}`)
+	if _, err := c.ImportBytes(context.Background(), projectID, "en.json", content, "en"); err != nil {
+		t.Fatal(err)
+	}
+	p, _ := srv.Project(projectID)
+	var names []string
+	for _, k := range p.Keys {
+		names = append(names, k.Name)
+	}
+	if got, want := strings.Join(names, ","), "greeting,farewell,apple,mango,zebra"; got != want {
+		t.Errorf("keys = %s, want %s", got, want)
+	}
+}
+
+func TestDownloadResume(t *testing.T) {
+	srv := newServer(t)
+	defer srv.Close()
+	var ranges []string
+	c := newClient(t, srv, lokalise.WithMiddleware(func(next lokalise.RoundTripFunc) lokalise.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			ranges = append(ranges, req.Header.Get("Range"))
+			return next(req)
+		}
+	}))
+	ctx := context.Background()
+
+	b, err := c.Export(ctx, projectID, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Get(b.FullFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("bundle served without ETag")
+	}
+
+	dir, err := ioutil.TempDir("", "lokalisetest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "bundle.zip")
+	half := len(content) / 2
+	ioutil.WriteFile(path+".part", content[:half], 0644)
+	ioutil.WriteFile(path+".part.validator", []byte(etag), 0644)
+
+	ranges = nil
+	if _, err := c.DownloadBundleFile(ctx, b, path); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := ioutil.ReadFile(path); !bytes.Equal(got, content) {
+		t.Errorf("downloaded %d bytes, want %d", len(got), len(content))
+	}
+	if want := fmt.Sprintf("bytes=%d-", half); len(ranges) != 1 || ranges[0] != want {
+		t.Errorf("ranges = %q, want [%q]", ranges, want)
+	}
+}
@@ -0,0 +1,64 @@
+package lokalisetest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+type snapshot struct {
+	id      int64
+	title   string
+	created time.Time
+	project Project
+}
+
+func (s *Server) snapshots(w http.ResponseWriter, endpoint string, req Request) {
+	p := s.project(req.Form.Get("id"))
+	if p == nil {
+		writeError(w, lokalise.AccessDenied, "Project not found")
+		return
+	}
+	switch endpoint {
+	case "snapshot/create":
+		title := req.Form.Get("title")
+		if title == "" {
+			writeError(w, lokalise.MissingRequestParameter, "Missing title")
+			return
+		}
+		snap := snapshot{id: s.nextID(), title: title, created: time.Now().UTC(), project: copyProject(*p)}
+		s.snaps[p.ID] = append(s.snaps[p.ID], snap)
+		writeJSON(w, map[string]interface{}{"snapshot": snap.details()})
+	case "snapshot/list":
+		snapshots := []map[string]interface{}{}
+		for _, snap := range s.snaps[p.ID] {
+			snapshots = append(snapshots, snap.details())
+		}
+		writeJSON(w, map[string]interface{}{"snapshots": snapshots})
+	case "snapshot/restore":
+		id, _ := strconv.ParseInt(req.Form.Get("snapshot_id"), 10, 64)
+		for _, snap := range s.snaps[p.ID] {
+			if snap.id != id {
+				continue
+			}
+			restored := copyProject(snap.project)
+			restored.ID = fmt.Sprintf("%s.%d", p.ID, s.nextID())
+			restored.Name = fmt.Sprintf("%s (%s)", p.Name, snap.title)
+			s.projects = append(s.projects, &restored)
+			writeJSON(w, map[string]interface{}{"project": restored.details()})
+			return
+		}
+		writeError(w, lokalise.Custom, fmt.Sprintf("Snapshot %d not found", id))
+	}
+}
+
+func (snap snapshot) details() map[string]interface{} {
+	return map[string]interface{}{
+		"snapshot_id": snap.id,
+		"title":       snap.title,
+		"created":     snap.created.Format("2006-01-02 15:04:05"),
+	}
+}
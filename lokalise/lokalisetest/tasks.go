@@ -0,0 +1,124 @@
+package lokalisetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+// details returns the task as served by the API.
+func (t *Task) details() map[string]interface{} {
+	status := t.Status
+	if status == "" {
+		status = lokalise.TaskInProgress
+	}
+	isos := make([]string, 0, len(t.Languages))
+	for iso := range t.Languages {
+		isos = append(isos, iso)
+	}
+	sort.Strings(isos)
+	languages := []lokalise.TaskLanguage{}
+	for _, iso := range isos {
+		languages = append(languages, lokalise.TaskLanguage{ISO: iso, Users: append([]int64{}, t.Languages[iso]...)})
+	}
+	var due interface{}
+	if !t.DueDate.IsZero() {
+		due = t.DueDate.Format("2006-01-02 15:04:05")
+	}
+	return map[string]interface{}{
+		"task_id":     t.ID,
+		"title":       t.Title,
+		"description": t.Description,
+		"status":      status,
+		"keys":        append([]int64{}, t.KeyIDs...),
+		"languages":   languages,
+		"due_date":    due,
+		"created":     t.Created.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func (s *Server) tasks(w http.ResponseWriter, endpoint string, req Request) {
+	p := s.project(req.Form.Get("id"))
+	if p == nil {
+		writeError(w, lokalise.AccessDenied, "Project not found")
+		return
+	}
+	switch endpoint {
+	case "task/list":
+		tasks := []map[string]interface{}{}
+		for i := range p.Tasks {
+			tasks = append(tasks, p.Tasks[i].details())
+		}
+		writeJSON(w, map[string]interface{}{"tasks": tasks})
+	case "task/close":
+		id, _ := strconv.ParseInt(req.Form.Get("task_id"), 10, 64)
+		for i := range p.Tasks {
+			if p.Tasks[i].ID == id {
+				p.Tasks[i].Status = lokalise.TaskClosed
+				writeJSON(w, map[string]interface{}{"task": p.Tasks[i].details()})
+				return
+			}
+		}
+		writeError(w, lokalise.Custom, fmt.Sprintf("Task %d not found", id))
+	case "task/create":
+		t := Task{
+			ID:          s.nextID(),
+			Title:       req.Form.Get("title"),
+			Description: req.Form.Get("description"),
+			Languages:   map[string][]int64{},
+			Created:     time.Now().UTC(),
+		}
+		if t.Title == "" {
+			writeError(w, lokalise.MissingRequestParameter, "Missing title")
+			return
+		}
+		var languages []lokalise.TaskLanguage
+		for field, dst := range map[string]interface{}{
+			"keys":      &t.KeyIDs,
+			"languages": &languages,
+		} {
+			if err := json.Unmarshal([]byte(req.Form.Get(field)), dst); err != nil {
+				writeError(w, lokalise.NotJSON, fmt.Sprintf("Invalid %s", field))
+				return
+			}
+		}
+		if len(t.KeyIDs) == 0 || len(languages) == 0 {
+			writeError(w, lokalise.MissingRequestParameter, "Missing keys or languages")
+			return
+		}
+		for _, id := range t.KeyIDs {
+			if p.keyByID(id) == nil {
+				writeError(w, lokalise.Custom, fmt.Sprintf("Key %d not found", id))
+				return
+			}
+		}
+		for _, l := range languages {
+			if !p.hasLanguage(l.ISO) {
+				writeError(w, lokalise.LanguageNotAvailable, fmt.Sprintf("Language %s is not available", l.ISO))
+				return
+			}
+			for _, user := range l.Users {
+				if p.contributor(user) == nil {
+					writeError(w, lokalise.Custom, fmt.Sprintf("Contributor %d not found", user))
+					return
+				}
+			}
+			t.Languages[l.ISO] = append([]int64{}, l.Users...)
+		}
+		if due := req.Form.Get("due_date"); due != "" {
+			d, err := time.Parse("2006-01-02 15:04:05", due)
+			if err != nil {
+				writeError(w, lokalise.Custom, "Invalid due_date")
+				return
+			}
+			t.DueDate = d
+		}
+		p.Tasks = append(p.Tasks, t)
+		writeJSON(w, map[string]interface{}{"task": t.details()})
+	}
+}
@@ -0,0 +1,142 @@
+package lokalisetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+// translation returns the translation of k into the language with code iso
+// as served by the translation endpoints.
+func (p *Project) translation(k *Key, iso string) map[string]interface{} {
+	var langIndex int
+	for i, l := range p.Languages {
+		if l == iso {
+			langIndex = i
+		}
+	}
+	status := k.Statuses[iso]
+	return map[string]interface{}{
+		"translation_id": k.ID*1000 + int64(langIndex),
+		"key_id":         k.ID,
+		"language_iso":   iso,
+		"translation":    k.Translations[iso],
+		"is_plural":      k.Plural,
+		"is_reviewed":    status.Reviewed,
+		"is_unverified":  status.Unverified,
+		"is_fuzzy":       status.Fuzzy,
+		"modified":       time.Now().UTC().Format("2006-01-02 15:04:05"),
+	}
+}
+
+func (s *Server) listTranslations(w http.ResponseWriter, req Request) {
+	p := s.project(req.Form.Get("id"))
+	if p == nil {
+		writeError(w, lokalise.AccessDenied, "Project not found")
+		return
+	}
+	var keyIDs []int64
+	var languages []string
+	if v := req.Form.Get("filter_keys"); v != "" {
+		if err := json.Unmarshal([]byte(v), &keyIDs); err != nil {
+			writeError(w, lokalise.Custom, "Invalid filter_keys")
+			return
+		}
+	}
+	if v := req.Form.Get("filter_langs"); v != "" {
+		if err := json.Unmarshal([]byte(v), &languages); err != nil {
+			writeError(w, lokalise.Custom, "Invalid filter_langs")
+			return
+		}
+	}
+	page, limit := 1, 100
+	if v := req.Form.Get("page"); v != "" {
+		page, _ = strconv.Atoi(v)
+	}
+	if v := req.Form.Get("limit"); v != "" {
+		limit, _ = strconv.Atoi(v)
+	}
+	if page < 1 || limit < 1 {
+		writeError(w, lokalise.Custom, "Invalid page or limit")
+		return
+	}
+
+	translations := []map[string]interface{}{}
+	for i := range p.Keys {
+		k := &p.Keys[i]
+		if len(keyIDs) > 0 && !containsID(keyIDs, k.ID) {
+			continue
+		}
+		for _, iso := range p.Languages {
+			if len(languages) > 0 && !contains(languages, iso) {
+				continue
+			}
+			translations = append(translations, p.translation(k, iso))
+		}
+	}
+	total := len(translations)
+	start, end := (page-1)*limit, page*limit
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+	writeJSON(w, map[string]interface{}{
+		"translations": translations[start:end],
+		"page":         page,
+		"page_count":   (total + limit - 1) / limit,
+		"total_count":  total,
+	})
+}
+
+func (s *Server) updateTranslation(w http.ResponseWriter, req Request) {
+	p := s.project(req.Form.Get("id"))
+	if p == nil {
+		writeError(w, lokalise.AccessDenied, "Project not found")
+		return
+	}
+	id, _ := strconv.ParseInt(req.Form.Get("key_id"), 10, 64)
+	k := p.keyByID(id)
+	if k == nil {
+		writeError(w, lokalise.Custom, fmt.Sprintf("Key %s not found", req.Form.Get("key_id")))
+		return
+	}
+	iso := req.Form.Get("lang_iso")
+	if !p.hasLanguage(iso) {
+		writeError(w, lokalise.LanguageNotAvailable, fmt.Sprintf("Language %s is not available", iso))
+		return
+	}
+	if t, ok := req.Form["translation"]; ok {
+		if k.Plural {
+			var plurals map[string]string
+			if err := json.Unmarshal([]byte(t[0]), &plurals); err != nil {
+				writeError(w, lokalise.NotJSON, "Translation of a plural key must be a JSON object")
+				return
+			}
+		}
+		if k.Translations == nil {
+			k.Translations = map[string]string{}
+		}
+		k.Translations[iso] = t[0]
+	}
+	if k.Statuses == nil {
+		k.Statuses = map[string]Status{}
+	}
+	status := k.Statuses[iso]
+	for field, dst := range map[string]*bool{
+		"is_reviewed":   &status.Reviewed,
+		"is_unverified": &status.Unverified,
+		"is_fuzzy":      &status.Fuzzy,
+	} {
+		if v, ok := req.Form[field]; ok {
+			*dst = v[0] == "1"
+		}
+	}
+	k.Statuses[iso] = status
+	writeJSON(w, map[string]interface{}{"translation": p.translation(k, iso)})
+}
@@ -0,0 +1,185 @@
+package lokalise
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// RoundTripFunc sends a single HTTP request and returns its response.
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTripFunc, e.g. to observe or modify requests and
+// responses.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware returns a ClientOption adding middleware to the chain every
+// request of the Client passes through, including retries and bundle
+// downloads. The first middleware added is the outermost.
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *Client) error {
+		for _, m := range mw {
+			if m == nil {
+				return errors.New("lokalise: middleware must not be nil")
+			}
+		}
+		c.middleware = append(c.middleware, mw...)
+		return nil
+	}
+}
+
+// chain wraps rt in the middleware of the Client.
+func (c *Client) chain(rt RoundTripFunc) RoundTripFunc {
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		rt = c.middleware[i](rt)
+	}
+	return rt
+}
+
+// A Logger logs a message with alternating key and value pairs, e.g.
+//
+//  log("lokalise response", "status", 200, "duration", time.Second)
+type Logger func(msg string, keyvals ...interface{})
+
+// LoggingMiddleware returns a Middleware logging every request and its
+// outcome to log. Request headers and URL encoded form fields are logged,
+// multipart bodies are not. The API token is redacted wherever it is sent,
+// i.e. in the api_token form field or query parameter and the X-Api-Token
+// header, as well as from error messages.
+func LoggingMiddleware(log Logger) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			u := redactURL(req.URL.String())
+			header, form, token := loggedRequest(req)
+			log("lokalise request", "method", req.Method, "url", u, "header", header, "form", form)
+			start := time.Now()
+			resp, err := next(req)
+			d := time.Since(start)
+			if err != nil {
+				log("lokalise error", "method", req.Method, "url", u, "duration", d, "error", redact(redactURL(err.Error()), token))
+				return resp, err
+			}
+			log("lokalise response", "method", req.Method, "url", u, "duration", d, "status", resp.StatusCode)
+			return resp, nil
+		}
+	}
+}
+
+// TimingMiddleware returns a Middleware calling observe with the duration of
+// every request. The response is nil if err is not.
+func TimingMiddleware(observe func(req *http.Request, resp *http.Response, d time.Duration, err error)) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			observe(req, resp, time.Since(start), err)
+			return resp, err
+		}
+	}
+}
+
+// A Tracer starts spans. It mirrors the OpenTelemetry tracing API, so an
+// adapter to an OpenTelemetry tracer is a few lines of code.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// A Span is a traced operation started by a Tracer.
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// TracingMiddleware returns a Middleware wrapping every request in a span
+// started with t. The span context is attached to the request, so inner
+// middleware and transports can propagate it. The span ends when the
+// response body is closed.
+func TracingMiddleware(t Tracer) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			ctx, span := t.Start(req.Context(), "lokalise "+req.URL.Path)
+			span.SetAttribute("http.method", req.Method)
+			span.SetAttribute("http.url", redactURL(req.URL.String()))
+			resp, err := next(req.WithContext(ctx))
+			if err != nil {
+				span.RecordError(errors.New(redactURL(err.Error())))
+				span.End()
+				return resp, err
+			}
+			span.SetAttribute("http.status_code", resp.StatusCode)
+			if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+				span.RecordError(errors.New(resp.Status))
+			}
+			if resp.Body == nil {
+				span.End()
+				return resp, nil
+			}
+			// The transfer of the body is part of the request.
+			resp.Body = &spanBody{ReadCloser: resp.Body, span: span}
+			return resp, nil
+		}
+	}
+}
+
+// spanBody ends span once the response body is closed.
+type spanBody struct {
+	io.ReadCloser
+	span Span
+	once sync.Once
+}
+
+func (b *spanBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err != io.EOF {
+		b.span.RecordError(err)
+	}
+	return n, err
+}
+
+func (b *spanBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.span.End)
+	return err
+}
+
+// loggedRequest returns the headers and URL encoded form fields of req with
+// the API token redacted, along with the token.
+func loggedRequest(req *http.Request) (http.Header, url.Values, string) {
+	header := req.Header.Clone()
+	token := header.Get("X-Api-Token")
+	if _, ok := header["X-Api-Token"]; ok {
+		header.Set("X-Api-Token", redacted)
+	}
+	var form url.Values
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType == "application/x-www-form-urlencoded" && req.GetBody != nil {
+		// GetBody returns a copy, leaving the body to be sent intact.
+		if body, err := req.GetBody(); err == nil {
+			content, err := ioutil.ReadAll(body)
+			body.Close()
+			if err == nil {
+				form, _ = url.ParseQuery(string(content))
+			}
+		}
+	}
+	if v := form.Get("api_token"); v != "" {
+		token = v
+		form.Set("api_token", redacted)
+	}
+	return header, form, token
+}
+
+var tokenParam = regexp.MustCompile(`(api_token=)[^&\s"]*`)
+
+// redactURL hides API tokens passed as query parameters in s.
+func redactURL(s string) string {
+	return tokenParam.ReplaceAllString(s, "${1}"+redacted)
+}
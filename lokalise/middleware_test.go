@@ -0,0 +1,197 @@
+package lokalise_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+type logEntry struct {
+	msg     string
+	keyvals []interface{}
+}
+
+// value returns the value logged for key.
+func (e logEntry) value(key string) interface{} {
+	for i := 0; i+1 < len(e.keyvals); i += 2 {
+		if e.keyvals[i] == key {
+			return e.keyvals[i+1]
+		}
+	}
+	return nil
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	srv := newScripted(reply{body: projectsBody})
+	defer srv.Close()
+	var logged []logEntry
+	logger := func(msg string, keyvals ...interface{}) {
+		logged = append(logged, logEntry{msg, keyvals})
+	}
+	// The token is also sent in a header, as with the API v2.
+	header := func(next lokalise.RoundTripFunc) lokalise.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Api-Token", apiToken)
+			return next(req)
+		}
+	}
+	c := newTestClient(t, srv.Server, lokalise.WithMiddleware(header, lokalise.LoggingMiddleware(logger)))
+
+	if _, err := c.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(logged) != 2 || logged[0].msg != "lokalise request" || logged[1].msg != "lokalise response" {
+		t.Fatalf("logged %v, want a request and a response", logged)
+	}
+	if s := fmt.Sprint(logged); strings.Contains(s, apiToken) {
+		t.Errorf("token logged: %s", s)
+	}
+	if h, _ := logged[0].value("header").(http.Header); h.Get("X-Api-Token") != "[REDACTED]" {
+		t.Errorf("logged header %v, want X-Api-Token redacted", h)
+	}
+	if f, _ := logged[0].value("form").(url.Values); f.Get("api_token") != "[REDACTED]" {
+		t.Errorf("logged form %v, want api_token redacted", f)
+	}
+	if got := logged[1].value("status"); got != http.StatusOK {
+		t.Errorf("logged status %v, want 200", got)
+	}
+	if req := srv.received()[0]; req.Form.Get("api_token") != apiToken || req.Header.Get("X-Api-Token") != apiToken {
+		t.Errorf("sent form %v and header %v, want the token unchanged", req.Form, req.Header)
+	}
+}
+
+func TestLoggingMiddlewareError(t *testing.T) {
+	var logged []logEntry
+	mw := lokalise.LoggingMiddleware(func(msg string, keyvals ...interface{}) {
+		logged = append(logged, logEntry{msg, keyvals})
+	})
+	rt := mw(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("rejected api_token " + apiToken)
+	})
+	req, _ := http.NewRequest(http.MethodPost, "https://api.lokalise.co/api/project/list", strings.NewReader("api_token="+apiToken))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if _, err := rt(req); err == nil {
+		t.Fatal("got no error")
+	}
+	if len(logged) != 2 || logged[1].msg != "lokalise error" {
+		t.Fatalf("logged %v, want a request and an error", logged)
+	}
+	if got := fmt.Sprint(logged[1].value("error")); strings.Contains(got, apiToken) {
+		t.Errorf("logged error %q, want the token redacted", got)
+	}
+}
+
+// tracer records the spans it starts.
+type tracer struct {
+	mu    sync.Mutex
+	spans []*span
+}
+
+func (tr *tracer) Start(ctx context.Context, name string) (context.Context, lokalise.Span) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	s := &span{name: name, attrs: map[string]interface{}{}}
+	tr.spans = append(tr.spans, s)
+	return ctx, s
+}
+
+type span struct {
+	mu    sync.Mutex
+	name  string
+	attrs map[string]interface{}
+	errs  []error
+	ended int
+}
+
+func (s *span) SetAttribute(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attrs[key] = value
+}
+
+func (s *span) RecordError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errs = append(s.errs, err)
+}
+
+func (s *span) End() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ended++
+}
+
+func (s *span) endCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ended
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	tr := &tracer{}
+	rt := lokalise.TracingMiddleware(tr)(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("bundle"))}, nil
+	})
+	req, _ := http.NewRequest(http.MethodGet, "https://s3.example.com/export/App.zip", nil)
+
+	resp, err := rt(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := tr.spans[0]
+	if s.endCount() != 0 {
+		t.Error("span ended before the body was read")
+	}
+	if _, err := ioutil.ReadAll(resp.Body); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	resp.Body.Close()
+	if n := s.endCount(); n != 1 {
+		t.Errorf("span ended %d times, want once", n)
+	}
+	if s.name != "lokalise /export/App.zip" || s.attrs["http.method"] != http.MethodGet || s.attrs["http.status_code"] != http.StatusOK {
+		t.Errorf("span %s with attributes %v", s.name, s.attrs)
+	}
+	if len(s.errs) != 0 {
+		t.Errorf("span recorded errors %v", s.errs)
+	}
+}
+
+func TestTracingMiddlewareError(t *testing.T) {
+	srv := newScripted(reply{status: http.StatusBadGateway})
+	defer srv.Close()
+	tr := &tracer{}
+	c := newTestClient(t, srv.Server, lokalise.WithRetryPolicy(lokalise.RetryPolicy{MaxAttempts: 1}), lokalise.WithMiddleware(lokalise.TracingMiddleware(tr)))
+
+	if _, err := c.List(context.Background()); err == nil {
+		t.Fatal("got no error")
+	}
+	if len(tr.spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(tr.spans))
+	}
+	s := tr.spans[0]
+	if s.endCount() != 1 || len(s.errs) != 1 || s.attrs["http.status_code"] != http.StatusBadGateway {
+		t.Errorf("span ended %d times with errors %v and attributes %v", s.endCount(), s.errs, s.attrs)
+	}
+
+	failing := lokalise.TracingMiddleware(tr)(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, nil)
+	if _, err := failing(req); err == nil {
+		t.Fatal("got no error")
+	}
+	if s := tr.spans[1]; s.endCount() != 1 || len(s.errs) != 1 {
+		t.Errorf("span ended %d times with errors %v, want once with the error", s.endCount(), s.errs)
+	}
+}
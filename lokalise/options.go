@@ -0,0 +1,177 @@
+package lokalise
+
+// ExportOptions is a declarative form of the ExportOption functions, for
+// loading export settings from configuration files, printing and comparing
+// them. Each field corresponds to the With* ExportOption of the same name,
+// e.g. Languages to WithLanguages, except ReplaceBreaks, which corresponds to
+// WithExportReplaceBreaks. Zero values and nil pointers leave the option
+// unset.
+//
+// The field tags follow the API parameter names, which the CLI uses as flag
+// names as well.
+type ExportOptions struct {
+	Languages               []string                `json:"langs,omitempty" toml:"langs,omitempty" yaml:"langs,omitempty"`
+	Original                *bool                   `json:"use_original,omitempty" toml:"use_original,omitempty" yaml:"use_original,omitempty"`
+	Filter                  []Filter                `json:"filter,omitempty" toml:"filter,omitempty" yaml:"filter,omitempty"`
+	BundleStructure         string                  `json:"bundle_structure,omitempty" toml:"bundle_structure,omitempty" yaml:"bundle_structure,omitempty"`
+	DirectoryPrefix         *string                 `json:"directory_prefix,omitempty" toml:"directory_prefix,omitempty" yaml:"directory_prefix,omitempty"`
+	WebhookURL              string                  `json:"webhook_url,omitempty" toml:"webhook_url,omitempty" yaml:"webhook_url,omitempty"`
+	All                     *bool                   `json:"export_all,omitempty" toml:"export_all,omitempty" yaml:"export_all,omitempty"`
+	Empty                   EmptyMode               `json:"export_empty,omitempty" toml:"export_empty,omitempty" yaml:"export_empty,omitempty"`
+	Comments                *bool                   `json:"include_comments,omitempty" toml:"include_comments,omitempty" yaml:"include_comments,omitempty"`
+	Description             *bool                   `json:"include_description,omitempty" toml:"include_description,omitempty" yaml:"include_description,omitempty"`
+	PIDs                    []string                `json:"include_pids,omitempty" toml:"include_pids,omitempty" yaml:"include_pids,omitempty"`
+	IncludeTags             []string                `json:"include_tags,omitempty" toml:"include_tags,omitempty" yaml:"include_tags,omitempty"`
+	ExcludeTags             []string                `json:"exclude_tags,omitempty" toml:"exclude_tags,omitempty" yaml:"exclude_tags,omitempty"`
+	SortOrder               SortOrder               `json:"export_sort,omitempty" toml:"export_sort,omitempty" yaml:"export_sort,omitempty"`
+	JavaPropertiesSeparator JavaPropertiesSeparator `json:"java_properties_separator,omitempty" toml:"java_properties_separator,omitempty" yaml:"java_properties_separator,omitempty"`
+	JavaPropertiesEncoding  JavaPropertiesEncoding  `json:"java_properties_encoding,omitempty" toml:"java_properties_encoding,omitempty" yaml:"java_properties_encoding,omitempty"`
+	ReplaceBreaks           *bool                   `json:"replace_breaks,omitempty" toml:"replace_breaks,omitempty" yaml:"replace_breaks,omitempty"`
+	YAMLRoot                *bool                   `json:"yaml_include_root,omitempty" toml:"yaml_include_root,omitempty" yaml:"yaml_include_root,omitempty"`
+	JSONUnescapedSlashes    *bool                   `json:"json_unescaped_slashes,omitempty" toml:"json_unescaped_slashes,omitempty" yaml:"json_unescaped_slashes,omitempty"`
+	NoLanguageFolders       *bool                   `json:"no_language_folders,omitempty" toml:"no_language_folders,omitempty" yaml:"no_language_folders,omitempty"`
+	Triggers                []Trigger               `json:"triggers,omitempty" toml:"triggers,omitempty" yaml:"triggers,omitempty"`
+	Repos                   []string                `json:"repos,omitempty" toml:"repos,omitempty" yaml:"repos,omitempty"`
+	PluralFormat            PluralFormat            `json:"plural_format,omitempty" toml:"plural_format,omitempty" yaml:"plural_format,omitempty"`
+	ICUNumeric              *bool                   `json:"icu_numeric,omitempty" toml:"icu_numeric,omitempty" yaml:"icu_numeric,omitempty"`
+	PercentEscape           *bool                   `json:"escape_percent,omitempty" toml:"escape_percent,omitempty" yaml:"escape_percent,omitempty"`
+	Indentation             Indentation             `json:"indentation,omitempty" toml:"indentation,omitempty" yaml:"indentation,omitempty"`
+	PlaceholderFormat       PlaceholderFormat       `json:"placeholder_format,omitempty" toml:"placeholder_format,omitempty" yaml:"placeholder_format,omitempty"`
+}
+
+// Options returns the ExportOptions for all set fields of o. Values are
+// validated when the options are applied, as with the With* functions.
+func (o ExportOptions) Options() []ExportOption {
+	var opts []ExportOption
+	addBool := func(v *bool, f func(bool) ExportOption) {
+		if v != nil {
+			opts = append(opts, f(*v))
+		}
+	}
+	addString := func(v string, f func(string) ExportOption) {
+		if v != "" {
+			opts = append(opts, f(v))
+		}
+	}
+	addStrings := func(v []string, f func(...string) ExportOption) {
+		if len(v) != 0 {
+			opts = append(opts, f(append([]string(nil), v...)...))
+		}
+	}
+	addStrings(o.Languages, WithLanguages)
+	addBool(o.Original, WithOriginal)
+	if len(o.Filter) != 0 {
+		opts = append(opts, WithFilter(o.Filter...))
+	}
+	addString(o.BundleStructure, WithBundleStructure)
+	if o.DirectoryPrefix != nil {
+		opts = append(opts, WithDirectoryPrefix(*o.DirectoryPrefix))
+	}
+	addString(o.WebhookURL, WithWebhookURL)
+	addBool(o.All, WithAll)
+	if o.Empty != "" {
+		opts = append(opts, WithEmpty(o.Empty))
+	}
+	addBool(o.Comments, WithComments)
+	addBool(o.Description, WithDescription)
+	addStrings(o.PIDs, WithPIDs)
+	addStrings(o.IncludeTags, WithIncludeTags)
+	addStrings(o.ExcludeTags, WithExcludeTags)
+	if o.SortOrder != "" {
+		opts = append(opts, WithSortOrder(o.SortOrder))
+	}
+	if o.JavaPropertiesSeparator != "" {
+		opts = append(opts, WithJavaPropertiesSeparator(o.JavaPropertiesSeparator))
+	}
+	if o.JavaPropertiesEncoding != "" {
+		opts = append(opts, WithJavaPropertiesEncoding(o.JavaPropertiesEncoding))
+	}
+	addBool(o.ReplaceBreaks, WithExportReplaceBreaks)
+	addBool(o.YAMLRoot, WithYAMLRoot)
+	addBool(o.JSONUnescapedSlashes, WithJSONUnescapedSlashes)
+	addBool(o.NoLanguageFolders, WithNoLanguageFolders)
+	if len(o.Triggers) != 0 {
+		opts = append(opts, WithTriggers(o.Triggers...))
+	}
+	addStrings(o.Repos, WithRepos)
+	if o.PluralFormat != "" {
+		opts = append(opts, WithPluralFormat(o.PluralFormat))
+	}
+	addBool(o.ICUNumeric, WithICUNumeric)
+	addBool(o.PercentEscape, WithPercentEscape)
+	if o.Indentation != "" {
+		opts = append(opts, WithIndentation(o.Indentation))
+	}
+	if o.PlaceholderFormat != "" {
+		opts = append(opts, WithPlaceholderFormat(o.PlaceholderFormat))
+	}
+	return opts
+}
+
+// ImportOptions is a declarative form of the ImportOption functions. Each
+// field corresponds to the With* ImportOption of the same name, e.g. Replace
+// to WithReplace, except ReplaceBreaks, which corresponds to
+// WithImportReplaceBreaks. Zero values and nil pointers leave the option
+// unset.
+type ImportOptions struct {
+	Replace             *bool    `json:"replace,omitempty" toml:"replace,omitempty" yaml:"replace,omitempty"`
+	ConvertPlaceholders *bool    `json:"convert_placeholders,omitempty" toml:"convert_placeholders,omitempty" yaml:"convert_placeholders,omitempty"`
+	SkipDetectLangIso   *bool    `json:"skip_detect_lang_iso,omitempty" toml:"skip_detect_lang_iso,omitempty" yaml:"skip_detect_lang_iso,omitempty"`
+	ICUPlurals          *bool    `json:"icu_plurals,omitempty" toml:"icu_plurals,omitempty" yaml:"icu_plurals,omitempty"`
+	FillEmpty           *bool    `json:"fill_empty,omitempty" toml:"fill_empty,omitempty" yaml:"fill_empty,omitempty"`
+	Distinguish         *bool    `json:"distinguish,omitempty" toml:"distinguish,omitempty" yaml:"distinguish,omitempty"`
+	TranslationMemory   *bool    `json:"use_trans_mem,omitempty" toml:"use_trans_mem,omitempty" yaml:"use_trans_mem,omitempty"`
+	Hidden              *bool    `json:"hidden,omitempty" toml:"hidden,omitempty" yaml:"hidden,omitempty"`
+	Tags                []string `json:"tags,omitempty" toml:"tags,omitempty" yaml:"tags,omitempty"`
+	TagInsertedKeys     []string `json:"tag_inserted_keys,omitempty" toml:"tag_inserted_keys,omitempty" yaml:"tag_inserted_keys,omitempty"`
+	TagUpdatedKeys      []string `json:"tag_updated_keys,omitempty" toml:"tag_updated_keys,omitempty" yaml:"tag_updated_keys,omitempty"`
+	TagSkippedKeys      []string `json:"tag_skipped_keys,omitempty" toml:"tag_skipped_keys,omitempty" yaml:"tag_skipped_keys,omitempty"`
+	Filename            string   `json:"filename,omitempty" toml:"filename,omitempty" yaml:"filename,omitempty"`
+	ReplaceBreaks       *bool    `json:"replace_breaks,omitempty" toml:"replace_breaks,omitempty" yaml:"replace_breaks,omitempty"`
+	CleanupMode         *bool    `json:"cleanup_mode,omitempty" toml:"cleanup_mode,omitempty" yaml:"cleanup_mode,omitempty"`
+}
+
+// Options returns the ImportOptions for all set fields of o.
+func (o ImportOptions) Options() []ImportOption {
+	var opts []ImportOption
+	addBool := func(v *bool, f func(bool) ImportOption) {
+		if v != nil {
+			opts = append(opts, f(*v))
+		}
+	}
+	addStrings := func(v []string, f func(...string) ImportOption) {
+		if len(v) != 0 {
+			opts = append(opts, f(append([]string(nil), v...)...))
+		}
+	}
+	addBool(o.Replace, WithReplace)
+	addBool(o.ConvertPlaceholders, WithConvertPlaceholders)
+	addBool(o.SkipDetectLangIso, WithSkipDetectLangIso)
+	addBool(o.ICUPlurals, WithICUPlurals)
+	addBool(o.FillEmpty, WithFillEmpty)
+	addBool(o.Distinguish, WithDistinguish)
+	addBool(o.TranslationMemory, WithTranslationMemory)
+	addBool(o.Hidden, WithHidden)
+	addStrings(o.Tags, WithTags)
+	addStrings(o.TagInsertedKeys, WithTagInsertedKeys)
+	addStrings(o.TagUpdatedKeys, WithTagUpdatedKeys)
+	addStrings(o.TagSkippedKeys, WithTagSkippedKeys)
+	if o.Filename != "" {
+		opts = append(opts, WithFilename(o.Filename))
+	}
+	addBool(o.ReplaceBreaks, WithImportReplaceBreaks)
+	addBool(o.CleanupMode, WithCleanupMode)
+	return opts
+}
+
+// Bool returns a pointer to v, for setting the optional fields of
+// ExportOptions and ImportOptions.
+func Bool(v bool) *bool {
+	return &v
+}
+
+// String returns a pointer to v, for setting the optional fields of
+// ExportOptions.
+func String(v string) *string {
+	return &v
+}
@@ -0,0 +1,156 @@
+package lokalise_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+const exportConfig = `
+langs = ["en", "de"]
+use_original = false
+filter = ["translated", "reviewed"]
+bundle_structure = "locale/%LANG_ISO%.%FORMAT%"
+directory_prefix = ""
+webhook_url = "https://example.com/hook"
+export_all = true
+export_empty = "base"
+include_comments = true
+include_description = false
+include_pids = ["1", "2"]
+include_tags = ["web"]
+exclude_tags = ["legacy"]
+export_sort = "a_z"
+java_properties_separator = ":"
+java_properties_encoding = "latin-1"
+replace_breaks = false
+yaml_include_root = true
+json_unescaped_slashes = true
+no_language_folders = true
+triggers = ["github"]
+repos = ["lokalise/app"]
+plural_format = "icu"
+icu_numeric = true
+escape_percent = true
+indentation = "2sp"
+placeholder_format = "ios"
+`
+
+func TestExportOptionsTOML(t *testing.T) {
+	var o lokalise.ExportOptions
+	if _, err := toml.Decode(exportConfig, &o); err != nil {
+		t.Fatal(err)
+	}
+	want := []lokalise.ExportOption{
+		lokalise.WithLanguages("en", "de"),
+		lokalise.WithOriginal(false),
+		lokalise.WithFilter(lokalise.FilterTranslated, lokalise.FilterReviewed),
+		lokalise.WithBundleStructure("locale/%LANG_ISO%.%FORMAT%"),
+		lokalise.WithDirectoryPrefix(""),
+		lokalise.WithWebhookURL("https://example.com/hook"),
+		lokalise.WithAll(true),
+		lokalise.WithEmpty(lokalise.EmptyAsBase),
+		lokalise.WithComments(true),
+		lokalise.WithDescription(false),
+		lokalise.WithPIDs("1", "2"),
+		lokalise.WithIncludeTags("web"),
+		lokalise.WithExcludeTags("legacy"),
+		lokalise.WithSortOrder(lokalise.SortAZ),
+		lokalise.WithJavaPropertiesSeparator(lokalise.PropertiesColon),
+		lokalise.WithJavaPropertiesEncoding(lokalise.PropertiesLatin1),
+		lokalise.WithExportReplaceBreaks(false),
+		lokalise.WithYAMLRoot(true),
+		lokalise.WithJSONUnescapedSlashes(true),
+		lokalise.WithNoLanguageFolders(true),
+		lokalise.WithTriggers(lokalise.TriggerGitHub),
+		lokalise.WithRepos("lokalise/app"),
+		lokalise.WithPluralFormat(lokalise.PluralICU),
+		lokalise.WithICUNumeric(true),
+		lokalise.WithPercentEscape(true),
+		lokalise.WithIndentation(lokalise.Indent2Spaces),
+		lokalise.WithPlaceholderFormat(lokalise.PlaceholderIOS),
+	}
+
+	got, wantForm := exportForm(t, o.Options()), exportForm(t, want)
+	if !reflect.DeepEqual(got, wantForm) {
+		t.Errorf("decoded options set\n%v\nwant\n%v", got, wantForm)
+	}
+}
+
+func exportForm(t *testing.T, opts []lokalise.ExportOption) url.Values {
+	t.Helper()
+	form := url.Values{}
+	for _, opt := range opts {
+		if err := opt(&form); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return form
+}
+
+const importConfig = `
+replace = true
+convert_placeholders = false
+skip_detect_lang_iso = true
+icu_plurals = true
+fill_empty = false
+distinguish = true
+use_trans_mem = true
+hidden = false
+tags = ["imported"]
+tag_inserted_keys = ["new"]
+tag_updated_keys = ["changed"]
+tag_skipped_keys = ["kept"]
+filename = "locale/en.json"
+replace_breaks = true
+cleanup_mode = true
+`
+
+func TestImportOptionsTOML(t *testing.T) {
+	var o lokalise.ImportOptions
+	if _, err := toml.Decode(importConfig, &o); err != nil {
+		t.Fatal(err)
+	}
+	want := []lokalise.ImportOption{
+		lokalise.WithReplace(true),
+		lokalise.WithConvertPlaceholders(false),
+		lokalise.WithSkipDetectLangIso(true),
+		lokalise.WithICUPlurals(true),
+		lokalise.WithFillEmpty(false),
+		lokalise.WithDistinguish(true),
+		lokalise.WithTranslationMemory(true),
+		lokalise.WithHidden(false),
+		lokalise.WithTags("imported"),
+		lokalise.WithTagInsertedKeys("new"),
+		lokalise.WithTagUpdatedKeys("changed"),
+		lokalise.WithTagSkippedKeys("kept"),
+		lokalise.WithFilename("locale/en.json"),
+		lokalise.WithImportReplaceBreaks(true),
+		lokalise.WithCleanupMode(true),
+	}
+
+	got, wantBody := importFields(t, o.Options()), importFields(t, want)
+	if !bytes.Equal(got, wantBody) {
+		t.Errorf("decoded options wrote\n%s\nwant\n%s", got, wantBody)
+	}
+}
+
+// importFields returns the multipart fields written by opts.
+func importFields(t *testing.T, opts []lokalise.ImportOption) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	w.SetBoundary("lokalise")
+	for _, opt := range opts {
+		if err := opt(w); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Close()
+	return buf.Bytes()
+}
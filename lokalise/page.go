@@ -0,0 +1,52 @@
+package lokalise
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+// Pagination is the position of a page returned by a paginated list call.
+type Pagination struct {
+	// Page is the number of the page, starting at 1.
+	Page       int `json:"page"`
+	PageCount  int `json:"page_count"`
+	TotalCount int `json:"total_count"`
+}
+
+// Next reports whether there are pages after p.
+func (p Pagination) Next() bool {
+	return p.Page < p.PageCount
+}
+
+// ListOption is a function setting filters and pagination for paginated list
+// calls like ListKeys and ListTranslations.
+type ListOption func(*url.Values) error
+
+// WithPage returns a ListOption selecting page number page, starting at 1,
+// with at most limit items per page. The API default is used for a limit of
+// 0.
+func WithPage(page, limit int) ListOption {
+	return func(v *url.Values) error {
+		if page < 1 || limit < 0 {
+			return errors.New("lokalise: page must be at least 1 and limit not negative")
+		}
+		v.Set("page", strconv.Itoa(page))
+		if limit > 0 {
+			v.Set("limit", strconv.Itoa(limit))
+		}
+		return nil
+	}
+}
+
+// listForm returns the form for a list call on project with ID projectID.
+func listForm(projectID string, opts []ListOption) (url.Values, error) {
+	form := &url.Values{}
+	form.Set("id", projectID)
+	for _, opt := range opts {
+		if err := opt(form); err != nil {
+			return nil, err
+		}
+	}
+	return *form, nil
+}
@@ -0,0 +1,153 @@
+package lokalise
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	defaultBundleStructure = "locale/%LANG_ISO%.%FORMAT%"
+	defaultDirectoryPrefix = "%LANG_ISO%/"
+)
+
+// templatePlaceholders holds the placeholders available in the templates of
+// the bundle_structure and directory_prefix options.
+var templatePlaceholders = map[string][]string{
+	"bundle_structure": {"%LANG_ISO%", "%LANG_NAME%", "%FORMAT%", "%PROJECT_NAME%"},
+	"directory_prefix": {"%LANG_ISO%"},
+}
+
+var placeholderPattern = regexp.MustCompile(`%[A-Za-z_]+%`)
+
+// BundlePath is a path in an export bundle as rendered by PreviewBundle.
+type BundlePath struct {
+	// Language is the ISO code of the language exported to the path.
+	Language string
+	Path     string
+}
+
+// PreviewBundle renders the paths an export of file type fileType with opts
+// would produce for a project named projectName with the given languages,
+// without sending a request.
+//
+// By default each language is exported to a single file, whose path is
+// rendered from the template of WithBundleStructure. With WithOriginal(true)
+// keys are exported to their assigned filenames instead, which are only known
+// to the API; Path is then the directory rendered from the template of
+// WithDirectoryPrefix.
+//
+// Only languages selected with WithLanguages are included. Templates with
+// unknown placeholders or paths leaving the bundle result in a
+// ValidationError.
+func PreviewBundle(projectName, fileType string, languages []Language, opts ...ExportOption) ([]BundlePath, error) {
+	form := url.Values{}
+	for _, opt := range opts {
+		if err := opt(&form); err != nil {
+			return nil, err
+		}
+	}
+
+	field, tmpl := "bundle_structure", defaultBundleStructure
+	if form.Get("use_original") == "1" {
+		field, tmpl = "directory_prefix", defaultDirectoryPrefix
+		if form.Get("no_language_folders") == "1" {
+			tmpl = ""
+		}
+	}
+	if v, ok := form[field]; ok {
+		tmpl = v[0]
+	}
+	if issues := checkTemplate(field, tmpl); len(issues) > 0 {
+		return nil, &ValidationError{Issues: issues}
+	}
+
+	selected := languages
+	if v := form.Get("langs"); v != "" {
+		var isos []string
+		if err := json.Unmarshal([]byte(v), &isos); err != nil {
+			return nil, err
+		}
+		selected = nil
+		var issues []Issue
+		for _, iso := range isos {
+			lang, ok := findLanguage(languages, iso)
+			if !ok {
+				issues = append(issues, Issue{Option: "langs", Message: "language " + iso + " is not in the project"})
+				continue
+			}
+			selected = append(selected, lang)
+		}
+		if len(issues) > 0 {
+			return nil, &ValidationError{Issues: issues}
+		}
+	}
+
+	format := fileType
+	if ft, ok := LookupFileType(fileType); ok {
+		format = ft.Extension
+	}
+	paths := make([]BundlePath, 0, len(selected))
+	for _, lang := range selected {
+		p := strings.NewReplacer(
+			"%LANG_ISO%", lang.ISO,
+			"%LANG_NAME%", lang.Name,
+			"%FORMAT%", format,
+			"%PROJECT_NAME%", projectName,
+		).Replace(tmpl)
+		if unsafePath(p) {
+			return nil, &ValidationError{Issues: []Issue{{
+				Option:  field,
+				Message: "path " + p + " for language " + lang.ISO + " leaves the bundle",
+			}}}
+		}
+		paths = append(paths, BundlePath{Language: lang.ISO, Path: p})
+	}
+	return paths, nil
+}
+
+// checkTemplate returns the issues of the template tmpl of the option field.
+func checkTemplate(field, tmpl string) []Issue {
+	var issues []Issue
+	for _, placeholder := range placeholderPattern.FindAllString(tmpl, -1) {
+		known := false
+		for _, p := range templatePlaceholders[field] {
+			known = known || placeholder == p
+		}
+		if !known {
+			issues = append(issues, Issue{
+				Option:  field,
+				Message: "unknown placeholder " + placeholder + ", expected one of " + strings.Join(templatePlaceholders[field], ", "),
+			})
+		}
+	}
+	if unsafePath(placeholderPattern.ReplaceAllString(tmpl, "x")) {
+		issues = append(issues, Issue{Option: field, Message: "absolute paths and .. are not allowed"})
+	}
+	return issues
+}
+
+// unsafePath reports whether p is absolute or contains a parent directory
+// element.
+func unsafePath(p string) bool {
+	p = strings.Replace(p, `\`, "/", -1)
+	if strings.HasPrefix(p, "/") || (len(p) > 1 && p[1] == ':') {
+		return true
+	}
+	for _, elem := range strings.Split(p, "/") {
+		if elem == ".." {
+			return true
+		}
+	}
+	return false
+}
+
+func findLanguage(languages []Language, iso string) (Language, bool) {
+	for _, lang := range languages {
+		if lang.ISO == iso {
+			return lang, true
+		}
+	}
+	return Language{}, false
+}
@@ -0,0 +1,131 @@
+package lokalise_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+func TestPreviewBundle(t *testing.T) {
+	languages := []lokalise.Language{
+		{ISO: "en", Name: "English"},
+		{ISO: "de", Name: "German"},
+		{ISO: "pt_BR", Name: "Portuguese (Brazil)"},
+	}
+	tests := []struct {
+		name     string
+		project  string
+		fileType string
+		opts     []lokalise.ExportOption
+		want     []lokalise.BundlePath
+		errors   string
+	}{
+		{
+			name:     "default structure",
+			fileType: "yaml",
+			want: []lokalise.BundlePath{
+				{Language: "en", Path: "locale/en.yml"},
+				{Language: "de", Path: "locale/de.yml"},
+				{Language: "pt_BR", Path: "locale/pt_BR.yml"},
+			},
+		},
+		{
+			name:     "custom structure of selected languages",
+			fileType: "json",
+			opts: []lokalise.ExportOption{
+				lokalise.WithBundleStructure("%PROJECT_NAME%/%LANG_NAME%.%FORMAT%"),
+				lokalise.WithLanguages("pt_BR", "en"),
+			},
+			want: []lokalise.BundlePath{
+				{Language: "pt_BR", Path: "App/Portuguese (Brazil).json"},
+				{Language: "en", Path: "App/English.json"},
+			},
+		},
+		{
+			name:     "unknown file type",
+			fileType: "docx",
+			opts:     []lokalise.ExportOption{lokalise.WithLanguages("en")},
+			want:     []lokalise.BundlePath{{Language: "en", Path: "locale/en.docx"}},
+		},
+		{
+			name:     "original filenames",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithOriginal(true), lokalise.WithLanguages("de")},
+			want:     []lokalise.BundlePath{{Language: "de", Path: "de/"}},
+		},
+		{
+			name:     "original filenames with prefix",
+			fileType: "json",
+			opts: []lokalise.ExportOption{
+				lokalise.WithOriginal(true),
+				lokalise.WithDirectoryPrefix("i18n/%LANG_ISO%/"),
+				lokalise.WithBundleStructure("ignored/%LANG_ISO%.json"),
+				lokalise.WithLanguages("de"),
+			},
+			want: []lokalise.BundlePath{{Language: "de", Path: "i18n/de/"}},
+		},
+		{
+			name:     "original filenames without language folders",
+			fileType: "json",
+			opts: []lokalise.ExportOption{
+				lokalise.WithOriginal(true),
+				lokalise.WithNoLanguageFolders(true),
+				lokalise.WithLanguages("de"),
+			},
+			want: []lokalise.BundlePath{{Language: "de", Path: ""}},
+		},
+		{
+			name:     "unknown placeholder",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithBundleStructure("%LANG_ISO%/%KEY%.%FORMAT%")},
+			errors:   "bundle_structure",
+		},
+		{
+			name:     "placeholder of other template",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithOriginal(true), lokalise.WithDirectoryPrefix("%LANG_NAME%/")},
+			errors:   "directory_prefix",
+		},
+		{
+			name:     "absolute path",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithBundleStructure("/etc/%LANG_ISO%.json")},
+			errors:   "bundle_structure",
+		},
+		{
+			name:     "parent directory",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithBundleStructure(`locale\..\..\%LANG_ISO%.json`)},
+			errors:   "bundle_structure",
+		},
+		{
+			name:     "project name leaving the bundle",
+			project:  "..",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithBundleStructure("%PROJECT_NAME%/%LANG_ISO%.json")},
+			errors:   "bundle_structure",
+		},
+		{
+			name:     "unknown language",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithLanguages("en", "fr")},
+			errors:   "langs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := tt.project
+			if project == "" {
+				project = "App"
+			}
+			paths, err := lokalise.PreviewBundle(project, tt.fileType, languages, tt.opts...)
+			if got := validationErrors(t, err); got != tt.errors {
+				t.Fatalf("error = %v, want issues of %q", err, tt.errors)
+			}
+			if fmt.Sprint(paths) != fmt.Sprint(tt.want) {
+				t.Errorf("paths = %+v, want %+v", paths, tt.want)
+			}
+		})
+	}
+}
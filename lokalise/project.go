@@ -0,0 +1,100 @@
+package lokalise
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// Project is the data model for a Lokalise project.
+type Project struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"desc"`
+	Created     Time   `json:"created"`
+	// Owner is "1" if the API token has admin rights on the project.
+	//
+	// Deprecated: Use Role instead.
+	Owner string `json:"owner"`
+	// Role is the role of the API token's user on the project. It is derived
+	// from Owner if the API does not report it.
+	Role Role `json:"role"`
+	// BaseLanguage is the ISO code of the base language, e.g. "en".
+	BaseLanguage string     `json:"base_lang_iso"`
+	Languages    []Language `json:"languages"`
+	// KeyCount and WordCount are the number of keys and of words in the
+	// base language.
+	KeyCount  int64 `json:"keys"`
+	WordCount int64 `json:"words"`
+	Team      Team  `json:"team"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (p *Project) UnmarshalJSON(b []byte) error {
+	type project Project
+	if err := json.Unmarshal(b, (*project)(p)); err != nil {
+		return err
+	}
+	if p.Role == "" {
+		p.Role = RoleContributor
+		if p.Owner == "1" {
+			p.Role = RoleAdmin
+		}
+	}
+	return nil
+}
+
+// Team is the team a project belongs to.
+type Team struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// Role is the role of a user on a project.
+type Role string
+
+// Roles of project users.
+const (
+	RoleOwner       Role = "owner"
+	RoleAdmin       Role = "admin"
+	RoleContributor Role = "contributor"
+)
+
+// IsAdmin reports whether the role has admin rights, which owners have too.
+func (r Role) IsAdmin() bool {
+	return r == RoleOwner || r == RoleAdmin
+}
+
+// CanExport reports whether the role may export the project.
+func (r Role) CanExport() bool {
+	return r.IsAdmin() || r == RoleContributor
+}
+
+// CanImport reports whether the role may import files into the project.
+func (r Role) CanImport() bool {
+	return r.IsAdmin()
+}
+
+// CanManage reports whether the role may manage the languages, keys,
+// contributors and snapshots of the project.
+func (r Role) CanManage() bool {
+	return r.IsAdmin()
+}
+
+type projectResponse struct {
+	Project  Project  `json:"project"`
+	Response response `json:"response"`
+}
+
+// GetProject returns the details of the project with ID projectID, including
+// the role of the API token's user, so that permissions can be checked before
+// starting long operations.
+func (c *Client) GetProject(ctx context.Context, projectID string) (Project, error) {
+	var dat projectResponse
+	form := url.Values{}
+	form.Set("id", projectID)
+	if err := c.do(ctx, "project/get", c.formRequest(ctx, form), &dat); err != nil {
+		return Project{}, err
+	}
+	return dat.Project, nil
+}
@@ -0,0 +1,91 @@
+package lokalise_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+	"github.com/lokalise/lokalise-cli-go/lokalise/lokalisetest"
+)
+
+// newFake returns a lokalisetest.Server serving p and a Client talking to it.
+func newFake(t *testing.T, p lokalisetest.Project) (*lokalisetest.Server, *lokalise.Client) {
+	t.Helper()
+	srv := lokalisetest.NewServer()
+	srv.AddProject(p)
+	c, err := srv.Client()
+	if err != nil {
+		srv.Close()
+		t.Fatal(err)
+	}
+	return srv, c
+}
+
+func TestGetProject(t *testing.T) {
+	srv, c := newFake(t, lokalisetest.Project{
+		ID:        projectID,
+		Name:      "App",
+		Admin:     true,
+		Languages: []string{"en", "de"},
+		Keys: []lokalisetest.Key{
+			{Name: "greeting", Translations: map[string]string{"en": "Hello world", "de": "Hallo Welt"}},
+		},
+	})
+	defer srv.Close()
+	ctx := context.Background()
+
+	p, err := c.GetProject(ctx, projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != projectID || p.Name != "App" || p.BaseLanguage != "en" || len(p.Languages) != 2 {
+		t.Errorf("project = %+v", p)
+	}
+	if p.KeyCount != 1 || p.WordCount != 2 {
+		t.Errorf("got %d keys and %d words, want 1 and 2", p.KeyCount, p.WordCount)
+	}
+	if p.Role != lokalise.RoleAdmin || !p.Role.CanImport() {
+		t.Errorf("role = %q, want admin", p.Role)
+	}
+
+	if _, err := c.GetProject(ctx, "999.zzz"); !errors.Is(err, lokalise.ErrAccessDenied) {
+		t.Errorf("unknown project: error = %v, want ErrAccessDenied", err)
+	}
+}
+
+func TestProjectRole(t *testing.T) {
+	tests := []struct {
+		name   string
+		admin  bool
+		role   lokalise.Role
+		want   lokalise.Role
+		manage bool
+	}{
+		{"admin from owner", true, "", lokalise.RoleAdmin, true},
+		{"contributor from owner", false, "", lokalise.RoleContributor, false},
+		{"owner", false, lokalise.RoleOwner, lokalise.RoleOwner, true},
+		{"contributor", false, lokalise.RoleContributor, lokalise.RoleContributor, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, c := newFake(t, lokalisetest.Project{ID: projectID, Name: "App", Admin: tt.admin, Role: tt.role, Languages: []string{"en"}})
+			defer srv.Close()
+
+			p, err := c.GetProject(context.Background(), projectID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Role != tt.want || p.Role.CanManage() != tt.manage || !p.Role.CanExport() {
+				t.Errorf("role = %q, want %q", p.Role, tt.want)
+			}
+			projects, err := c.List(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(projects) != 1 || projects[0].Role != tt.want {
+				t.Errorf("listed projects %+v, want role %q", projects, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package lokalise
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// WithRateLimit returns a ClientOption limiting the Client to rps requests
+// per second on average, allowing bursts of up to burst requests.
+//
+// The limit is shared by all goroutines using the Client and applies to
+// every attempt, including retries.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return func(c *Client) error {
+		if rps <= 0 {
+			return errors.New("lokalise: rate limit must be positive")
+		}
+		if burst < 1 {
+			return errors.New("lokalise: rate limit burst must be at least 1")
+		}
+		c.limiter = newLimiter(rps, burst)
+		return nil
+	}
+}
+
+// limiter is a token bucket. Callers reserve a token up front and sleep
+// until it becomes available, so waiting goroutines are served in order.
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(rps float64, burst int) *limiter {
+	return &limiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done.
+func (l *limiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens--
+	var d time.Duration
+	if l.tokens < 0 {
+		d = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if err := sleep(ctx, d); err != nil {
+		// Hand the reserved token back to the next caller.
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
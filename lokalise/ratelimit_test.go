@@ -0,0 +1,77 @@
+package lokalise_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+func TestRateLimitBurst(t *testing.T) {
+	srv := newScripted(reply{body: projectsBody})
+	defer srv.Close()
+	c := newTestClient(t, srv.Server, lokalise.WithRateLimit(0.1, 3))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := c.List(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Waiting for a token would take 10s.
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("burst took %v, want no waiting", d)
+	}
+}
+
+func TestRateLimitRefill(t *testing.T) {
+	srv := newScripted(reply{body: projectsBody})
+	defer srv.Close()
+	c := newTestClient(t, srv.Server, lokalise.WithRateLimit(20, 1))
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := c.List(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The first request uses the burst, the other four wait 50ms each.
+	if d := time.Since(start); d < 190*time.Millisecond {
+		t.Errorf("5 requests took %v, want at least 200ms", d)
+	}
+}
+
+func TestRateLimitCanceled(t *testing.T) {
+	srv := newScripted(reply{body: projectsBody})
+	defer srv.Close()
+	c := newTestClient(t, srv.Server, lokalise.WithRateLimit(0.01, 1))
+
+	if _, err := c.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.List(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("took %v, want the wait to end with the context", d)
+	}
+	if n := len(srv.received()); n != 1 {
+		t.Errorf("got %d requests, want 1", n)
+	}
+}
+
+func TestWithRateLimitInvalid(t *testing.T) {
+	for _, tt := range []struct {
+		rps   float64
+		burst int
+	}{{0, 1}, {-1, 1}, {1, 0}} {
+		if _, err := lokalise.NewClient(lokalise.WithRateLimit(tt.rps, tt.burst)); err == nil {
+			t.Errorf("WithRateLimit(%v, %d): got no error", tt.rps, tt.burst)
+		}
+	}
+}
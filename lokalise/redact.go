@@ -0,0 +1,63 @@
+package lokalise
+
+import (
+	"net/http"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// redact replaces every occurrence of secret in s.
+func redact(s, secret string) string {
+	if secret == "" {
+		return s
+	}
+	return strings.Replace(s, secret, redacted, -1)
+}
+
+// redactHeader returns h with every occurrence of secret replaced. h is copied
+// if it contains secret and returned unchanged otherwise.
+func redactHeader(h http.Header, secret string) http.Header {
+	if secret == "" {
+		return h
+	}
+	var out http.Header
+	for k, vs := range h {
+		for i, v := range vs {
+			if !strings.Contains(v, secret) {
+				continue
+			}
+			if out == nil {
+				out = h.Clone()
+			}
+			out[k][i] = redact(v, secret)
+		}
+	}
+	if out == nil {
+		return h
+	}
+	return out
+}
+
+// redactedError hides a secret from the message of the wrapped error while
+// keeping it available to errors.Is and errors.As.
+type redactedError struct {
+	err    error
+	secret string
+}
+
+func (err *redactedError) Error() string {
+	return redact(err.err.Error(), err.secret)
+}
+
+func (err *redactedError) Unwrap() error {
+	return err.err
+}
+
+// redactError wraps err if its message contains the API token of the client.
+func (c *Client) redactError(err error) error {
+	if err == nil || c.apiToken == "" || !strings.Contains(err.Error(), c.apiToken) {
+		return err
+	}
+	return &redactedError{err: err, secret: c.apiToken}
+}
@@ -0,0 +1,96 @@
+package lokalise_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+func TestTokenOnlyInBody(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lokalise")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "en.json")
+	if err := ioutil.WriteFile(file, []byte(`{"greeting":"Hello"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := newScripted(reply{body: projectsBody}, reply{body: importBody})
+	defer srv.Close()
+	c := newTestClient(t, srv.Server)
+	ctx := context.Background()
+
+	if _, err := c.List(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Import(ctx, projectID, file, "en"); err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.received()
+	if len(reqs) != 2 {
+		t.Fatalf("got %d requests, want 2", len(reqs))
+	}
+	for _, req := range reqs {
+		if strings.Contains(req.URL, apiToken) || strings.Contains(fmt.Sprint(req.Header), apiToken) {
+			t.Errorf("token sent outside the body: %s %v", req.URL, req.Header)
+		}
+		if got := req.Form.Get("api_token"); got != apiToken {
+			t.Errorf("%s: api_token = %q, want %q", req.URL, got, apiToken)
+		}
+	}
+}
+
+func TestErrorRedactsToken(t *testing.T) {
+	// The server echoes the token of the request in its errors.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.FormValue("api_token")
+		if strings.HasSuffix(r.URL.Path, "/project/list") {
+			w.Header().Set("X-Echo", "token="+token)
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "bad request from %s", token)
+			return
+		}
+		fmt.Fprintf(w, `{"response":{"status":"error","code":"4002","message":"Invalid token %s"}}`, token)
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+	ctx := context.Background()
+
+	_, err := c.List(ctx)
+	if err == nil {
+		t.Fatal("got no error")
+	}
+	if strings.Contains(err.Error(), apiToken) || !strings.Contains(err.Error(), "[REDACTED]") {
+		t.Errorf("error = %v, want the token replaced", err)
+	}
+	var httpErr *lokalise.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("error = %#v, want an HTTPError with status 400", err)
+	}
+	if string(httpErr.Body) != "bad request from [REDACTED]" {
+		t.Errorf("body = %q, want the token replaced", httpErr.Body)
+	}
+	if got := httpErr.Header.Get("X-Echo"); got != "token=[REDACTED]" {
+		t.Errorf("header X-Echo = %q, want the token replaced", got)
+	}
+
+	_, err = c.Export(ctx, projectID, "json")
+	var apiErr *lokalise.Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error = %v, want an Error", err)
+	}
+	if apiErr.Message != "Invalid token [REDACTED]" {
+		t.Errorf("message = %q, want the token replaced", apiErr.Message)
+	}
+}
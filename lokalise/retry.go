@@ -0,0 +1,139 @@
+package lokalise
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how a Client retries requests that failed with a
+// RateLimit error, a 5xx HTTP status or a network error, as reported by
+// IsRetryable. Requests creating or removing resources, e.g. CreateKeys,
+// CreateSnapshot or RemoveLanguage, are only retried after rate limiting or
+// a failure to connect, since repeating them could create duplicates or fail
+// on resources already removed.
+//
+// The delay between attempts grows exponentially from MinBackoff up to
+// MaxBackoff with random jitter applied. If the API or the bundle host
+// responds with a Retry-After header, that delay is used instead, capped at
+// MaxBackoff.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a request, including
+	// the first one. Values below 2 disable retries.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry. If zero, the
+	// MinBackoff of DefaultRetryPolicy is used, capped at MaxBackoff.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts. If zero, the
+	// MaxBackoff of DefaultRetryPolicy is used, raised to MinBackoff.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is the RetryPolicy of a Client created without
+// WithRetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  time.Second,
+	MaxBackoff:  30 * time.Second,
+}
+
+// WithRetryPolicy returns a ClientOption setting the RetryPolicy of the
+// Client. Use RetryPolicy{MaxAttempts: 1} to disable retries.
+//
+// Zero backoffs are taken from DefaultRetryPolicy, so that
+// RetryPolicy{MaxAttempts: 6} only changes the number of attempts.
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		if p.MinBackoff < 0 || p.MaxBackoff < 0 {
+			return errors.New("lokalise: retry backoff must not be negative")
+		}
+		if p.MaxBackoff < p.MinBackoff && p.MaxBackoff != 0 {
+			return errors.New("lokalise: max retry backoff must not be less than min backoff")
+		}
+		if p.MinBackoff == 0 {
+			p.MinBackoff = DefaultRetryPolicy.MinBackoff
+			if p.MaxBackoff != 0 && p.MaxBackoff < p.MinBackoff {
+				p.MinBackoff = p.MaxBackoff
+			}
+		}
+		if p.MaxBackoff == 0 {
+			p.MaxBackoff = DefaultRetryPolicy.MaxBackoff
+			if p.MaxBackoff < p.MinBackoff {
+				p.MaxBackoff = p.MinBackoff
+			}
+		}
+		c.retry = p
+		return nil
+	}
+}
+
+// backoff returns the delay before retry number n, starting at 1.
+func (p RetryPolicy) backoff(n int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < n && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	// Full jitter in the upper half keeps concurrent clients from retrying in lockstep.
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// delay returns the delay before retry number n, starting at 1. A delay
+// requested with Retry-After, if not zero, takes precedence over the backoff
+// but is capped at MaxBackoff.
+func (p RetryPolicy) delay(n int, retryAfter time.Duration) time.Duration {
+	if retryAfter > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	if retryAfter > 0 {
+		return retryAfter
+	}
+	return p.backoff(n)
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an
+// HTTP date. It returns false if the header is absent or malformed.
+func retryAfter(h http.Header) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done, whichever happens first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
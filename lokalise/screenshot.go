@@ -0,0 +1,143 @@
+package lokalise
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Screenshot is the data model for a screenshot giving translators context.
+type Screenshot struct {
+	ID          int64    `json:"screenshot_id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	// KeyIDs holds the IDs of the keys linked to the screenshot.
+	KeyIDs []int64 `json:"key_ids"`
+	// URL is the location of the uploaded image.
+	URL     string `json:"url"`
+	Created Time   `json:"created"`
+}
+
+// ScreenshotOption is a function setting options for a screenshot upload.
+type ScreenshotOption func(*multipart.Writer) error
+
+// WithScreenshotTitle returns a ScreenshotOption setting the title of the
+// screenshot. The API defaults to the filename.
+func WithScreenshotTitle(title string) ScreenshotOption {
+	return func(w *multipart.Writer) error {
+		return w.WriteField("title", title)
+	}
+}
+
+// WithScreenshotDescription returns a ScreenshotOption setting the
+// description of the screenshot.
+func WithScreenshotDescription(description string) ScreenshotOption {
+	return func(w *multipart.Writer) error {
+		return w.WriteField("description", description)
+	}
+}
+
+// WithScreenshotTags returns a ScreenshotOption setting the tags of the
+// screenshot.
+func WithScreenshotTags(tags ...string) ScreenshotOption {
+	return func(w *multipart.Writer) error {
+		return writeJSONField(w, "tags", tags)
+	}
+}
+
+// WithScreenshotKeys returns a ScreenshotOption linking the screenshot to the
+// keys with the given IDs.
+func WithScreenshotKeys(keyIDs ...int64) ScreenshotOption {
+	return func(w *multipart.Writer) error {
+		return writeJSONField(w, "key_ids", keyIDs)
+	}
+}
+
+func writeJSONField(w *multipart.Writer, field string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return w.WriteField(field, string(data))
+}
+
+type screenshotsResponse struct {
+	Screenshots []Screenshot `json:"screenshots"`
+	Response    response     `json:"response"`
+}
+
+type screenshotResponse struct {
+	Screenshot Screenshot `json:"screenshot"`
+	Response   response   `json:"response"`
+}
+
+// UploadScreenshotFile uploads the image file at path to project with ID
+// projectID, see UploadScreenshot. The file is streamed to the API.
+func (c *Client) UploadScreenshotFile(ctx context.Context, projectID, path string, opts ...ScreenshotOption) (Screenshot, error) {
+	form := screenshotForm(projectID, opts)
+	newRequest := func(u string) (*http.Request, error) {
+		return c.newFileUploadRequest(ctx, u, path, form)
+	}
+	return c.uploadScreenshot(ctx, newRequest)
+}
+
+// UploadScreenshot uploads the image content named name to project with ID
+// projectID and returns the new screenshot. Link it to keys with
+// WithScreenshotKeys.
+func (c *Client) UploadScreenshot(ctx context.Context, projectID, name string, content []byte, opts ...ScreenshotOption) (Screenshot, error) {
+	form := screenshotForm(projectID, opts)
+	newRequest := func(u string) (*http.Request, error) {
+		return c.newUploadRequest(ctx, u, name, ioutil.NopCloser(bytes.NewReader(content)), int64(len(content)), form)
+	}
+	return c.uploadScreenshot(ctx, newRequest)
+}
+
+func (c *Client) uploadScreenshot(ctx context.Context, newRequest func(u string) (*http.Request, error)) (Screenshot, error) {
+	var dat screenshotResponse
+	if err := c.doUnlessProcessed(ctx, "screenshot/upload", newRequest, &dat); err != nil {
+		return Screenshot{}, err
+	}
+	return dat.Screenshot, nil
+}
+
+// screenshotForm returns the form fields of a screenshot upload besides the
+// file.
+func screenshotForm(projectID string, opts []ScreenshotOption) uploadForm {
+	return func(w *multipart.Writer) error {
+		if err := w.WriteField("id", projectID); err != nil {
+			return err
+		}
+		for _, opt := range opts {
+			if err := opt(w); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// ListScreenshots returns the screenshots of project with ID projectID.
+func (c *Client) ListScreenshots(ctx context.Context, projectID string) ([]Screenshot, error) {
+	form := url.Values{}
+	form.Set("id", projectID)
+	var dat screenshotsResponse
+	if err := c.do(ctx, "screenshot/list", c.formRequest(ctx, form), &dat); err != nil {
+		return nil, err
+	}
+	return dat.Screenshots, nil
+}
+
+// DeleteScreenshot deletes the screenshot with ID screenshotID from project
+// with ID projectID.
+func (c *Client) DeleteScreenshot(ctx context.Context, projectID string, screenshotID int64) error {
+	form := url.Values{}
+	form.Set("id", projectID)
+	form.Set("screenshot_id", strconv.FormatInt(screenshotID, 10))
+	return c.doUnlessProcessed(ctx, "screenshot/delete", c.formRequest(ctx, form), &struct{}{})
+}
@@ -0,0 +1,91 @@
+package lokalise_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+	"github.com/lokalise/lokalise-cli-go/lokalise/lokalisetest"
+)
+
+var screenshotContent = []byte("\x89PNG\r\n\x1a\nhome screen")
+
+func TestScreenshots(t *testing.T) {
+	srv, c := newFake(t, lokalisetest.Project{
+		ID:        projectID,
+		Name:      "App",
+		Admin:     true,
+		Languages: []string{"en"},
+		Keys:      []lokalisetest.Key{{Name: "greeting"}},
+	})
+	defer srv.Close()
+	ctx := context.Background()
+	p, _ := srv.Project(projectID)
+	keyID := p.Keys[0].ID
+
+	shot, err := c.UploadScreenshot(ctx, projectID, "home.png", screenshotContent,
+		lokalise.WithScreenshotTitle("Home"),
+		lokalise.WithScreenshotDescription("Start page"),
+		lokalise.WithScreenshotTags("web"),
+		lokalise.WithScreenshotKeys(keyID),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shot.ID == 0 || shot.Title != "Home" || shot.Description != "Start page" || len(shot.Tags) != 1 || len(shot.KeyIDs) != 1 || shot.KeyIDs[0] != keyID {
+		t.Errorf("uploaded screenshot %+v", shot)
+	}
+	resp, err := http.Get(shot.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	image, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !bytes.Equal(image, screenshotContent) {
+		t.Errorf("served image %q, want %q", image, screenshotContent)
+	}
+
+	// The title defaults to the filename.
+	path, cleanup := writeTemp(t, "settings.png", screenshotContent)
+	defer cleanup()
+	fromFile, err := c.UploadScreenshotFile(ctx, projectID, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromFile.Title != "settings" {
+		t.Errorf("title = %q, want settings", fromFile.Title)
+	}
+
+	shots, err := c.ListScreenshots(ctx, projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shots) != 2 || shots[0].ID != shot.ID || shots[1].ID != fromFile.ID {
+		t.Errorf("screenshots = %+v, want both uploads", shots)
+	}
+
+	if err := c.DeleteScreenshot(ctx, projectID, shot.ID); err != nil {
+		t.Fatal(err)
+	}
+	if shots, _ := c.ListScreenshots(ctx, projectID); len(shots) != 1 || shots[0].ID != fromFile.ID {
+		t.Errorf("screenshots = %+v, want the second upload only", shots)
+	}
+	if err := c.DeleteScreenshot(ctx, projectID, shot.ID); err == nil {
+		t.Error("deleting twice: got no error")
+	}
+}
+
+func TestUploadScreenshotUnknownKey(t *testing.T) {
+	srv, c := newFake(t, lokalisetest.Project{ID: projectID, Name: "App", Admin: true, Languages: []string{"en"}})
+	defer srv.Close()
+
+	if _, err := c.UploadScreenshot(context.Background(), projectID, "home.png", screenshotContent, lokalise.WithScreenshotKeys(42)); err == nil {
+		t.Error("got no error")
+	}
+	if p, _ := srv.Project(projectID); len(p.Screenshots) != 0 {
+		t.Errorf("screenshots = %+v, want none", p.Screenshots)
+	}
+}
@@ -0,0 +1,61 @@
+package lokalise
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// Snapshot is the data model for a snapshot of a project.
+type Snapshot struct {
+	ID      int64  `json:"snapshot_id"`
+	Title   string `json:"title"`
+	Created Time   `json:"created"`
+}
+
+type snapshotsResponse struct {
+	Snapshots []Snapshot `json:"snapshots"`
+	Response  response   `json:"response"`
+}
+
+type snapshotResponse struct {
+	Snapshot Snapshot `json:"snapshot"`
+	Response response `json:"response"`
+}
+
+// CreateSnapshot takes a snapshot of project with ID projectID titled title.
+func (c *Client) CreateSnapshot(ctx context.Context, projectID, title string) (Snapshot, error) {
+	form := url.Values{}
+	form.Set("id", projectID)
+	form.Set("title", title)
+	var dat snapshotResponse
+	if err := c.doUnlessProcessed(ctx, "snapshot/create", c.formRequest(ctx, form), &dat); err != nil {
+		return Snapshot{}, err
+	}
+	return dat.Snapshot, nil
+}
+
+// ListSnapshots returns the snapshots of project with ID projectID.
+func (c *Client) ListSnapshots(ctx context.Context, projectID string) ([]Snapshot, error) {
+	form := url.Values{}
+	form.Set("id", projectID)
+	var dat snapshotsResponse
+	if err := c.do(ctx, "snapshot/list", c.formRequest(ctx, form), &dat); err != nil {
+		return nil, err
+	}
+	return dat.Snapshots, nil
+}
+
+// RestoreSnapshot restores the snapshot with ID snapshotID of project with ID
+// projectID. The API restores snapshots to a new copy of the project, which is
+// returned; the project itself is left unchanged.
+func (c *Client) RestoreSnapshot(ctx context.Context, projectID string, snapshotID int64) (Project, error) {
+	form := url.Values{}
+	form.Set("id", projectID)
+	form.Set("snapshot_id", strconv.FormatInt(snapshotID, 10))
+	var dat projectResponse
+	if err := c.doUnlessProcessed(ctx, "snapshot/restore", c.formRequest(ctx, form), &dat); err != nil {
+		return Project{}, err
+	}
+	return dat.Project, nil
+}
@@ -0,0 +1,138 @@
+package lokalise
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Task is the data model for a translation task assigning keys to
+// contributors.
+type Task struct {
+	ID          int64      `json:"task_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      TaskStatus `json:"status"`
+	// KeyIDs holds the IDs of the keys to translate.
+	KeyIDs    []int64        `json:"keys"`
+	Languages []TaskLanguage `json:"languages"`
+	// DueDate is zero for tasks without a due date.
+	DueDate Time `json:"due_date"`
+	Created Time `json:"created"`
+}
+
+// TaskLanguage is a language of a task along with its assignees.
+type TaskLanguage struct {
+	// ISO is the language code.
+	ISO string `json:"language_iso"`
+	// Users holds the user IDs of the contributors assigned to the language.
+	Users []int64 `json:"users"`
+}
+
+// TaskStatus is the status of a task.
+type TaskStatus string
+
+// Statuses of tasks.
+const (
+	TaskInProgress TaskStatus = "in progress"
+	TaskCompleted  TaskStatus = "completed"
+	TaskClosed     TaskStatus = "closed"
+)
+
+// TaskOption is a function setting options for creating a task.
+type TaskOption func(*url.Values) error
+
+// WithTaskDescription returns a TaskOption setting the description of the
+// task.
+func WithTaskDescription(description string) TaskOption {
+	return TaskOption(stringField("description", description))
+}
+
+// WithDueDate returns a TaskOption setting the date the task is due.
+func WithDueDate(due time.Time) TaskOption {
+	return func(v *url.Values) error {
+		v.Set("due_date", due.UTC().Format(timeFormat))
+		return nil
+	}
+}
+
+type tasksResponse struct {
+	Tasks    []Task   `json:"tasks"`
+	Response response `json:"response"`
+}
+
+type taskResponse struct {
+	Task     Task     `json:"task"`
+	Response response `json:"response"`
+}
+
+// CreateTask creates a task named title in project with ID projectID to
+// translate the keys with IDs keyIDs into languages and returns it. Assign
+// contributors with the Users of each language.
+func (c *Client) CreateTask(ctx context.Context, projectID, title string, keyIDs []int64, languages []TaskLanguage, opts ...TaskOption) (Task, error) {
+	if strings.TrimSpace(title) == "" {
+		return Task{}, errors.New("lokalise: task title is required")
+	}
+	if len(keyIDs) == 0 {
+		return Task{}, errors.New("lokalise: task without keys")
+	}
+	if len(languages) == 0 {
+		return Task{}, errors.New("lokalise: task without languages")
+	}
+	for _, l := range languages {
+		if strings.TrimSpace(l.ISO) == "" {
+			return Task{}, errors.New("lokalise: task language without language code")
+		}
+	}
+	form := &url.Values{}
+	form.Set("id", projectID)
+	form.Set("title", title)
+	keys, err := json.Marshal(keyIDs)
+	if err != nil {
+		return Task{}, err
+	}
+	form.Set("keys", string(keys))
+	langs, err := json.Marshal(languages)
+	if err != nil {
+		return Task{}, err
+	}
+	form.Set("languages", string(langs))
+	for _, opt := range opts {
+		if err := opt(form); err != nil {
+			return Task{}, err
+		}
+	}
+	var dat taskResponse
+	if err := c.doUnlessProcessed(ctx, "task/create", c.formRequest(ctx, *form), &dat); err != nil {
+		return Task{}, err
+	}
+	return dat.Task, nil
+}
+
+// ListTasks returns the tasks of project with ID projectID.
+func (c *Client) ListTasks(ctx context.Context, projectID string) ([]Task, error) {
+	form := url.Values{}
+	form.Set("id", projectID)
+	var dat tasksResponse
+	if err := c.do(ctx, "task/list", c.formRequest(ctx, form), &dat); err != nil {
+		return nil, err
+	}
+	return dat.Tasks, nil
+}
+
+// CloseTask closes the task with ID taskID of project with ID projectID and
+// returns it.
+func (c *Client) CloseTask(ctx context.Context, projectID string, taskID int64) (Task, error) {
+	form := url.Values{}
+	form.Set("id", projectID)
+	form.Set("task_id", strconv.FormatInt(taskID, 10))
+	var dat taskResponse
+	if err := c.do(ctx, "task/close", c.formRequest(ctx, form), &dat); err != nil {
+		return Task{}, err
+	}
+	return dat.Task, nil
+}
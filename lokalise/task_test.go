@@ -0,0 +1,108 @@
+package lokalise_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+	"github.com/lokalise/lokalise-cli-go/lokalise/lokalisetest"
+)
+
+func TestTasks(t *testing.T) {
+	srv, c := newFake(t, lokalisetest.Project{
+		ID:           projectID,
+		Name:         "App",
+		Admin:        true,
+		Languages:    []string{"en", "de"},
+		Keys:         []lokalisetest.Key{{Name: "greeting"}, {Name: "farewell"}},
+		Contributors: []lokalisetest.Contributor{{Email: "translator@example.com"}},
+	})
+	defer srv.Close()
+	ctx := context.Background()
+	p, _ := srv.Project(projectID)
+	keyIDs := []int64{p.Keys[0].ID, p.Keys[1].ID}
+	userID := p.Contributors[0].ID
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	task, err := c.CreateTask(ctx, projectID, "Release 42", keyIDs,
+		[]lokalise.TaskLanguage{{ISO: "de", Users: []int64{userID}}},
+		lokalise.WithTaskDescription("Strings of the release"),
+		lokalise.WithDueDate(due),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.ID == 0 || task.Title != "Release 42" || task.Description != "Strings of the release" || task.Status != lokalise.TaskInProgress {
+		t.Errorf("created task %+v", task)
+	}
+	if len(task.KeyIDs) != 2 || len(task.Languages) != 1 || task.Languages[0].ISO != "de" || len(task.Languages[0].Users) != 1 || task.Languages[0].Users[0] != userID {
+		t.Errorf("task assigns keys %v and languages %+v", task.KeyIDs, task.Languages)
+	}
+	if !task.DueDate.Equal(due) {
+		t.Errorf("due date = %v, want %v", task.DueDate, due)
+	}
+
+	tasks, err := c.ListTasks(ctx, projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != task.ID {
+		t.Errorf("tasks = %+v, want the created task", tasks)
+	}
+
+	closed, err := c.CloseTask(ctx, projectID, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed.ID != task.ID || closed.Status != lokalise.TaskClosed {
+		t.Errorf("closed task %+v", closed)
+	}
+	if _, err := c.CloseTask(ctx, projectID, task.ID+100); err == nil {
+		t.Error("unknown task: got no error")
+	}
+}
+
+func TestCreateTaskErrors(t *testing.T) {
+	srv, c := newFake(t, lokalisetest.Project{
+		ID:        projectID,
+		Name:      "App",
+		Admin:     true,
+		Languages: []string{"en", "de"},
+		Keys:      []lokalisetest.Key{{Name: "greeting"}},
+	})
+	defer srv.Close()
+	ctx := context.Background()
+	p, _ := srv.Project(projectID)
+	keyIDs := []int64{p.Keys[0].ID}
+	de := []lokalise.TaskLanguage{{ISO: "de"}}
+
+	if _, err := c.CreateTask(ctx, projectID, "Release 42", keyIDs, []lokalise.TaskLanguage{{ISO: "fr"}}); err == nil {
+		t.Error("unknown language: got no error")
+	}
+	if _, err := c.CreateTask(ctx, projectID, "Release 42", []int64{p.Keys[0].ID + 100}, de); err == nil {
+		t.Error("unknown key: got no error")
+	}
+
+	// Incomplete tasks are rejected before sending the request.
+	n := len(srv.Requests())
+	tests := []struct {
+		name      string
+		title     string
+		keyIDs    []int64
+		languages []lokalise.TaskLanguage
+	}{
+		{"no title", " ", keyIDs, de},
+		{"no keys", "Release 42", nil, de},
+		{"no languages", "Release 42", keyIDs, nil},
+		{"no language code", "Release 42", keyIDs, []lokalise.TaskLanguage{{}}},
+	}
+	for _, tt := range tests {
+		if _, err := c.CreateTask(ctx, projectID, tt.title, tt.keyIDs, tt.languages); err == nil {
+			t.Errorf("%s: got no error", tt.name)
+		}
+	}
+	if got := len(srv.Requests()); got != n {
+		t.Errorf("got %d requests for incomplete tasks, want none", got-n)
+	}
+}
@@ -0,0 +1,203 @@
+package lokalise
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+// Translation is the data model for the translation of a key into a language.
+type Translation struct {
+	ID    int64
+	KeyID int64
+	// Language is the ISO code of the language.
+	Language string
+	// Text is the translation of a singular key.
+	Text string
+	// Plurals maps the plural forms of the language, e.g. "one" and "other",
+	// to the translations of a plural key. It is nil for singular keys.
+	Plurals    map[string]string
+	Reviewed   bool
+	Unverified bool
+	Fuzzy      bool
+	Modified   Time
+}
+
+// translation is the wire format of a Translation, which holds the plural
+// forms of plural keys as a JSON encoded string.
+type translation struct {
+	ID          int64  `json:"translation_id"`
+	KeyID       int64  `json:"key_id"`
+	Language    string `json:"language_iso"`
+	Translation string `json:"translation"`
+	Plural      bool   `json:"is_plural"`
+	Reviewed    bool   `json:"is_reviewed"`
+	Unverified  bool   `json:"is_unverified"`
+	Fuzzy       bool   `json:"is_fuzzy"`
+	Modified    Time   `json:"modified"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Translation) UnmarshalJSON(b []byte) error {
+	var w translation
+	if err := json.Unmarshal(b, &w); err != nil {
+		return err
+	}
+	*t = Translation{
+		ID:         w.ID,
+		KeyID:      w.KeyID,
+		Language:   w.Language,
+		Reviewed:   w.Reviewed,
+		Unverified: w.Unverified,
+		Fuzzy:      w.Fuzzy,
+		Modified:   w.Modified,
+	}
+	if !w.Plural {
+		t.Text = w.Translation
+		return nil
+	}
+	t.Plurals = map[string]string{}
+	if w.Translation == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(w.Translation), &t.Plurals)
+}
+
+// TranslationPage is a page of translations returned by ListTranslations.
+type TranslationPage struct {
+	Translations []Translation `json:"translations"`
+	Pagination
+}
+
+// WithKeyIDs returns a ListOption limiting translations to those of the keys
+// with the given IDs. For ListTranslations only.
+func WithKeyIDs(ids ...int64) ListOption {
+	return func(v *url.Values) error {
+		data, err := json.Marshal(ids)
+		if err != nil {
+			return err
+		}
+		v.Set("filter_keys", string(data))
+		return nil
+	}
+}
+
+// WithLanguageISOs returns a ListOption limiting translations to those into
+// the languages with the given ISO codes. For ListTranslations only.
+func WithLanguageISOs(isos ...string) ListOption {
+	return ListOption(stringArrayField("filter_langs", isos))
+}
+
+// TranslationOption is a function setting changes for UpdateTranslation.
+type TranslationOption func(*url.Values) error
+
+// WithText returns a TranslationOption setting the translation of a singular
+// key. It cannot be combined with WithPlurals.
+func WithText(text string) TranslationOption {
+	return func(v *url.Values) error {
+		if err := checkTranslationUnset(v); err != nil {
+			return err
+		}
+		v.Set("translation", text)
+		return nil
+	}
+}
+
+// WithPlurals returns a TranslationOption setting the translations of a
+// plural key by plural form. Allowed forms are "zero", "one", "two", "few",
+// "many" and "other". It cannot be combined with WithText.
+func WithPlurals(plurals map[string]string) TranslationOption {
+	return func(v *url.Values) error {
+		if err := checkTranslationUnset(v); err != nil {
+			return err
+		}
+		for form := range plurals {
+			if err := allowedStrings(pluralForms...)(form); err != nil {
+				return err
+			}
+		}
+		data, err := json.Marshal(plurals)
+		if err != nil {
+			return err
+		}
+		v.Set("translation", string(data))
+		return nil
+	}
+}
+
+// checkTranslationUnset returns an error if WithText or WithPlurals set the
+// translation already.
+func checkTranslationUnset(v *url.Values) error {
+	if _, ok := (*v)["translation"]; ok {
+		return errors.New("lokalise: translation set more than once, use either WithText or WithPlurals")
+	}
+	return nil
+}
+
+// WithReviewed returns a TranslationOption marking the translation as
+// reviewed or not.
+func WithReviewed(enabled bool) TranslationOption {
+	return TranslationOption(boolField("is_reviewed", enabled))
+}
+
+// WithUnverified returns a TranslationOption marking the translation as
+// unverified or not.
+func WithUnverified(enabled bool) TranslationOption {
+	return TranslationOption(boolField("is_unverified", enabled))
+}
+
+// WithFuzzy returns a TranslationOption marking the translation as fuzzy or
+// not.
+func WithFuzzy(enabled bool) TranslationOption {
+	return TranslationOption(boolField("is_fuzzy", enabled))
+}
+
+type translationListResponse struct {
+	TranslationPage
+	Response response `json:"response"`
+}
+
+type translationResponse struct {
+	Translation Translation `json:"translation"`
+	Response    response    `json:"response"`
+}
+
+// ListTranslations returns a page of translations of project with ID
+// projectID, the first one unless WithPage is set. Limit the translations
+// to keys and languages with WithKeyIDs and WithLanguageISOs.
+func (c *Client) ListTranslations(ctx context.Context, projectID string, opts ...ListOption) (TranslationPage, error) {
+	form, err := listForm(projectID, opts)
+	if err != nil {
+		return TranslationPage{}, err
+	}
+	var dat translationListResponse
+	if err := c.do(ctx, "translation/list", c.formRequest(ctx, form), &dat); err != nil {
+		return TranslationPage{}, err
+	}
+	return dat.TranslationPage, nil
+}
+
+// UpdateTranslation changes the translation of the key with ID keyID into
+// the language with code langISO in project with ID projectID as set by opts
+// and returns it.
+func (c *Client) UpdateTranslation(ctx context.Context, projectID string, keyID int64, langISO string, opts ...TranslationOption) (Translation, error) {
+	if len(opts) == 0 {
+		return Translation{}, errors.New("lokalise: no translation changes")
+	}
+	form := &url.Values{}
+	form.Set("id", projectID)
+	form.Set("key_id", strconv.FormatInt(keyID, 10))
+	form.Set("lang_iso", langISO)
+	for _, opt := range opts {
+		if err := opt(form); err != nil {
+			return Translation{}, err
+		}
+	}
+	var dat translationResponse
+	if err := c.do(ctx, "translation/update", c.formRequest(ctx, *form), &dat); err != nil {
+		return Translation{}, err
+	}
+	return dat.Translation, nil
+}
@@ -0,0 +1,96 @@
+package lokalise_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+	"github.com/lokalise/lokalise-cli-go/lokalise/lokalisetest"
+)
+
+func TestTranslationDecoding(t *testing.T) {
+	tests := []struct {
+		name        string
+		json        string
+		wantText    string
+		wantPlurals map[string]string
+	}{
+		{"singular", `{"translation":"Hello","is_plural":false}`, "Hello", nil},
+		{"plural", `{"translation":"{\"one\":\"1 apple\",\"other\":\"%d apples\"}","is_plural":true}`, "", map[string]string{"one": "1 apple", "other": "%d apples"}},
+		{"untranslated plural", `{"translation":"","is_plural":true}`, "", map[string]string{}},
+	}
+	for _, tt := range tests {
+		var tr lokalise.Translation
+		if err := json.Unmarshal([]byte(tt.json), &tr); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if tr.Text != tt.wantText || !reflect.DeepEqual(tr.Plurals, tt.wantPlurals) {
+			t.Errorf("%s: got text %q and plurals %v, want %q and %v", tt.name, tr.Text, tr.Plurals, tt.wantText, tt.wantPlurals)
+		}
+	}
+
+	var tr lokalise.Translation
+	if err := json.Unmarshal([]byte(`{"translation":"1 apple","is_plural":true}`), &tr); err == nil {
+		t.Error("plural translation that is not a JSON object: got no error")
+	}
+}
+
+func TestUpdatePluralTranslation(t *testing.T) {
+	srv, c := newFake(t, lokalisetest.Project{
+		ID:        projectID,
+		Name:      "App",
+		Admin:     true,
+		Languages: []string{"en"},
+		Keys: []lokalisetest.Key{
+			{Name: "apples", Plural: true, Translations: map[string]string{"en": `{"one":"1 apple","other":"apples"}`}},
+		},
+	})
+	defer srv.Close()
+	ctx := context.Background()
+	p, _ := srv.Project(projectID)
+	keyID := p.Keys[0].ID
+
+	want := map[string]string{"one": "1 apple", "other": "%d apples"}
+	tr, err := c.UpdateTranslation(ctx, projectID, keyID, "en", lokalise.WithPlurals(want), lokalise.WithReviewed(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tr.Plurals, want) || tr.Text != "" || !tr.Reviewed {
+		t.Errorf("translation = %+v, want the reviewed plural forms", tr)
+	}
+
+	page, err := c.ListTranslations(ctx, projectID, lokalise.WithKeyIDs(keyID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Translations) != 1 || !reflect.DeepEqual(page.Translations[0].Plurals, want) {
+		t.Errorf("translations = %+v, want the plural forms", page.Translations)
+	}
+}
+
+func TestUpdateTranslationTextAndPlurals(t *testing.T) {
+	srv, c := newFake(t, lokalisetest.Project{
+		ID:        projectID,
+		Name:      "App",
+		Admin:     true,
+		Languages: []string{"en"},
+		Keys:      []lokalisetest.Key{{Name: "greeting"}},
+	})
+	defer srv.Close()
+	p, _ := srv.Project(projectID)
+
+	for _, opts := range [][]lokalise.TranslationOption{
+		{lokalise.WithText("Hello"), lokalise.WithPlurals(map[string]string{"other": "Hellos"})},
+		{lokalise.WithPlurals(map[string]string{"other": "Hellos"}), lokalise.WithText("Hello")},
+	} {
+		if _, err := c.UpdateTranslation(context.Background(), projectID, p.Keys[0].ID, "en", opts...); err == nil {
+			t.Error("got no error")
+		}
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Errorf("got %d requests, want none", n)
+	}
+}
@@ -0,0 +1,213 @@
+package lokalise
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// An Issue describes a problem with an option found by ValidateExport or
+// ValidateImport.
+type Issue struct {
+	// Option is the API parameter name of the option, e.g. "yaml_include_root".
+	Option  string
+	Message string
+}
+
+// String returns the issue in the form "option: message".
+func (i Issue) String() string {
+	return i.Option + ": " + i.Message
+}
+
+// ValidationError is returned by ValidateExport and ValidateImport for option
+// sets that cannot produce the intended result.
+type ValidationError struct {
+	Issues []Issue
+}
+
+// Error implements the error interface.
+func (err *ValidationError) Error() string {
+	msgs := make([]string, len(err.Issues))
+	for i, issue := range err.Issues {
+		msgs[i] = issue.String()
+	}
+	return "lokalise: invalid options: " + strings.Join(msgs, "; ")
+}
+
+// Assert that ValidationError implements the error interface.
+var _ error = &ValidationError{}
+
+type validation struct {
+	warnings []Issue
+	errors   []Issue
+}
+
+func (v *validation) warn(option, format string, args ...interface{}) {
+	v.warnings = append(v.warnings, Issue{Option: option, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validation) fail(option, format string, args ...interface{}) {
+	v.errors = append(v.errors, Issue{Option: option, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validation) result() ([]Issue, error) {
+	if len(v.errors) > 0 {
+		return v.warnings, &ValidationError{Issues: v.errors}
+	}
+	return v.warnings, nil
+}
+
+// ValidateExport checks opts for an export of file type fileType before any
+// request is sent.
+//
+// Options that are ignored or have no effect for fileType, e.g. WithYAMLRoot
+// for a JSON export, are returned as warnings. Conflicting options, e.g. the
+// same tag both included and excluded, result in a ValidationError. Invalid
+// option values result in the error of the option itself.
+func ValidateExport(fileType string, opts ...ExportOption) ([]Issue, error) {
+	form := url.Values{}
+	for _, opt := range opts {
+		if err := opt(&form); err != nil {
+			return nil, err
+		}
+	}
+	var v validation
+	if fileType == "" {
+		v.fail("type", "file type is required")
+	} else if _, ok := LookupFileType(fileType); !ok {
+		v.warn("type", "unknown file type %s, expected one of %s", fileType, strings.Join(AllowedValues("type"), ", "))
+	}
+	original := form.Get("use_original") == "1"
+	enabled := func(field string) bool {
+		return form.Get(field) == "1"
+	}
+
+	if _, ok := form["bundle_structure"]; ok && original {
+		v.warn("bundle_structure", "ignored when use_original is enabled")
+	}
+	if _, ok := form["directory_prefix"]; ok && !original {
+		v.warn("directory_prefix", "ignored unless use_original is enabled")
+	}
+	for _, field := range []string{"bundle_structure", "directory_prefix"} {
+		if tmpl, ok := form[field]; ok {
+			v.errors = append(v.errors, checkTemplate(field, tmpl[0])...)
+		}
+	}
+	if tmpl := form.Get("bundle_structure"); tmpl != "" && !strings.Contains(tmpl, "%LANG_ISO%") && !strings.Contains(tmpl, "%LANG_NAME%") {
+		v.warn("bundle_structure", "has no language placeholder, all languages are exported to the same path")
+	}
+	if _, ok := form["directory_prefix"]; ok && enabled("no_language_folders") {
+		v.fail("no_language_folders", "conflicts with directory_prefix, use directory_prefix only")
+	}
+	if enabled("yaml_include_root") && fileType != "yaml" {
+		v.warn("yaml_include_root", "only available for yaml exports, not %s", fileType)
+	}
+	if enabled("json_unescaped_slashes") && fileType != "json" {
+		v.warn("json_unescaped_slashes", "only available for json exports, not %s", fileType)
+	}
+	for _, field := range []string{"java_properties_encoding", "java_properties_separator"} {
+		if _, ok := form[field]; ok && fileType != "properties" {
+			v.warn(field, "only available for properties exports, not %s", fileType)
+		}
+	}
+	if enabled("icu_numeric") && PluralFormat(form.Get("plural_format")) != PluralICU {
+		v.warn("icu_numeric", "only works with plural_format icu")
+	}
+	if enabled("escape_percent") {
+		switch format := PlaceholderFormat(form.Get("placeholder_format")); format {
+		case PlaceholderPrintf:
+		case "":
+			v.warn("escape_percent", "only works with the printf placeholder format, set placeholder_format to be sure")
+		default:
+			v.fail("escape_percent", "only works with the printf placeholder format, not %s", format)
+		}
+	}
+	if enabled("include_comments") && enabled("include_description") {
+		v.warn("include_description", "has no effect, include_comments already includes descriptions")
+	}
+	if _, ok := form["repos"]; ok && form.Get("triggers") == "" {
+		v.warn("repos", "ignored unless triggers are set")
+	}
+
+	var included, excluded []string
+	json.Unmarshal([]byte(form.Get("include_tags")), &included)
+	json.Unmarshal([]byte(form.Get("exclude_tags")), &excluded)
+	for _, tag := range included {
+		for _, ex := range excluded {
+			if tag == ex {
+				v.fail("exclude_tags", "tag %q is both included and excluded", tag)
+			}
+		}
+	}
+	return v.result()
+}
+
+// Validate checks the options for an export of file type fileType, see
+// ValidateExport.
+func (o ExportOptions) Validate(fileType string) ([]Issue, error) {
+	return ValidateExport(fileType, o.Options()...)
+}
+
+// ValidateImport checks opts for an import of the file filename before any
+// request is sent. Issues are reported as for ValidateExport.
+func ValidateImport(filename string, opts ...ImportOption) ([]Issue, error) {
+	form, err := importFields(opts)
+	if err != nil {
+		return nil, err
+	}
+	var v validation
+	if override := form.Get("filename"); override != "" && !strings.EqualFold(filepath.Ext(override), filepath.Ext(filename)) {
+		v.warn("filename", "extension of %q differs from the uploaded file %q", override, filepath.Base(filename))
+	}
+	if form.Get("tags") != "" {
+		for _, field := range []string{"tag_inserted_keys", "tag_updated_keys"} {
+			if form.Get(field) != "" {
+				v.warn(field, "combined with tags, which already apply to inserted and updated keys")
+			}
+		}
+	}
+	return v.result()
+}
+
+// Validate checks the options for an import of the file filename, see
+// ValidateImport.
+func (o ImportOptions) Validate(filename string) ([]Issue, error) {
+	return ValidateImport(filename, o.Options()...)
+}
+
+// importFields returns the form fields opts write to a multipart body.
+func importFields(opts []ImportOption) (url.Values, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for _, opt := range opts {
+		if err := opt(w); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	form := url.Values{}
+	r := multipart.NewReader(&body, w.Boundary())
+	for {
+		part, err := r.NextPart()
+		if errors.Is(err, io.EOF) {
+			return form, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		value, err := ioutil.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		form.Add(part.FormName(), string(value))
+	}
+}
@@ -0,0 +1,245 @@
+package lokalise_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+)
+
+// issueOptions returns the options of issues joined by commas.
+func issueOptions(issues []lokalise.Issue) string {
+	opts := make([]string, len(issues))
+	for i, issue := range issues {
+		opts[i] = issue.Option
+	}
+	return strings.Join(opts, ",")
+}
+
+// validationErrors returns the options of the issues of a ValidationError
+// joined by commas.
+func validationErrors(t *testing.T, err error) string {
+	t.Helper()
+	if err == nil {
+		return ""
+	}
+	var verr *lokalise.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("error = %v, want a ValidationError", err)
+	}
+	return issueOptions(verr.Issues)
+}
+
+func TestValidateExport(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileType string
+		opts     []lokalise.ExportOption
+		warnings string
+		errors   string
+	}{
+		{name: "no options", fileType: "json"},
+		{
+			name:     "consistent options",
+			fileType: "yaml",
+			opts: []lokalise.ExportOption{
+				lokalise.WithBundleStructure("%PROJECT_NAME%/%LANG_NAME%.%FORMAT%"),
+				lokalise.WithYAMLRoot(true),
+				lokalise.WithPlaceholderFormat(lokalise.PlaceholderPrintf),
+				lokalise.WithPercentEscape(true),
+				lokalise.WithIncludeTags("web"),
+				lokalise.WithExcludeTags("ios"),
+			},
+		},
+		{name: "missing type", errors: "type"},
+		{name: "unknown type", fileType: "docx", warnings: "type"},
+		{
+			name:     "directory prefix without original",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithDirectoryPrefix("%LANG_ISO%/")},
+			warnings: "directory_prefix",
+		},
+		{
+			name:     "bundle structure with original",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithOriginal(true), lokalise.WithBundleStructure("%LANG_ISO%.json")},
+			warnings: "bundle_structure",
+		},
+		{
+			name:     "bundle structure without language",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithBundleStructure("locale/all.%FORMAT%")},
+			warnings: "bundle_structure",
+		},
+		{
+			name:     "options of other file types",
+			fileType: "xml",
+			opts: []lokalise.ExportOption{
+				lokalise.WithYAMLRoot(true),
+				lokalise.WithJSONUnescapedSlashes(true),
+				lokalise.WithJavaPropertiesEncoding(lokalise.PropertiesUTF8),
+				lokalise.WithJavaPropertiesSeparator(lokalise.PropertiesEquals),
+			},
+			warnings: "yaml_include_root,json_unescaped_slashes,java_properties_encoding,java_properties_separator",
+		},
+		{
+			name:     "disabled options of other file types",
+			fileType: "xml",
+			opts:     []lokalise.ExportOption{lokalise.WithYAMLRoot(false), lokalise.WithJSONUnescapedSlashes(false)},
+		},
+		{
+			name:     "icu numeric without icu",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithICUNumeric(true), lokalise.WithPluralFormat(lokalise.PluralArray)},
+			warnings: "icu_numeric",
+		},
+		{
+			name:     "percent escape without placeholder format",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithPercentEscape(true)},
+			warnings: "escape_percent",
+		},
+		{
+			name:     "percent escape with other placeholder format",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithPercentEscape(true), lokalise.WithPlaceholderFormat(lokalise.PlaceholderIOS)},
+			errors:   "escape_percent",
+		},
+		{
+			name:     "description with comments",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithComments(true), lokalise.WithDescription(true)},
+			warnings: "include_description",
+		},
+		{
+			name:     "repos without triggers",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithRepos("app")},
+			warnings: "repos",
+		},
+		{
+			name:     "repos with triggers",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithRepos("app"), lokalise.WithTriggers(lokalise.TriggerAmazonS3)},
+		},
+		{
+			name:     "tag included and excluded",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithIncludeTags("web", "ios"), lokalise.WithExcludeTags("ios")},
+			errors:   "exclude_tags",
+		},
+		{
+			name:     "no language folders with directory prefix",
+			fileType: "json",
+			opts: []lokalise.ExportOption{
+				lokalise.WithOriginal(true),
+				lokalise.WithDirectoryPrefix("%LANG_ISO%/"),
+				lokalise.WithNoLanguageFolders(true),
+			},
+			errors: "no_language_folders",
+		},
+		{
+			name:     "unknown placeholder",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithBundleStructure("%LANG_ISO%/%KEY%.%FORMAT%")},
+			errors:   "bundle_structure",
+		},
+		{
+			name:     "template leaving the bundle",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithOriginal(true), lokalise.WithDirectoryPrefix("../%LANG_ISO%/")},
+			errors:   "directory_prefix",
+		},
+		{
+			name:     "warnings along with errors",
+			fileType: "json",
+			opts:     []lokalise.ExportOption{lokalise.WithBundleStructure("/%LANG_ISO%.json"), lokalise.WithOriginal(true)},
+			warnings: "bundle_structure",
+			errors:   "bundle_structure",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := lokalise.ValidateExport(tt.fileType, tt.opts...)
+			if got := issueOptions(warnings); got != tt.warnings {
+				t.Errorf("warnings = %v, want issues of %q", warnings, tt.warnings)
+			}
+			if got := validationErrors(t, err); got != tt.errors {
+				t.Errorf("error = %v, want issues of %q", err, tt.errors)
+			}
+		})
+	}
+}
+
+func TestValidateExportOptionError(t *testing.T) {
+	_, err := lokalise.ValidateExport("json", lokalise.WithSortOrder("bogus"))
+	var verr *lokalise.ValidationError
+	if err == nil || errors.As(err, &verr) {
+		t.Errorf("error = %v, want the error of the option", err)
+	}
+}
+
+func TestExportOptionsValidate(t *testing.T) {
+	enabled := true
+	o := lokalise.ExportOptions{
+		YAMLRoot:    &enabled,
+		IncludeTags: []string{"web"},
+		ExcludeTags: []string{"web"},
+	}
+	warnings, err := o.Validate("json")
+	if got := issueOptions(warnings); got != "yaml_include_root" {
+		t.Errorf("warnings = %v, want yaml_include_root", warnings)
+	}
+	if got := validationErrors(t, err); got != "exclude_tags" {
+		t.Errorf("error = %v, want exclude_tags", err)
+	}
+}
+
+func TestValidateImport(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		opts     []lokalise.ImportOption
+		warnings string
+	}{
+		{name: "no options", filename: "locale/en.json"},
+		{
+			name:     "filename with same extension",
+			filename: "locale/en.JSON",
+			opts:     []lokalise.ImportOption{lokalise.WithFilename("%LANG_ISO%.json")},
+		},
+		{
+			name:     "filename with other extension",
+			filename: "locale/en.json",
+			opts:     []lokalise.ImportOption{lokalise.WithFilename("%LANG_ISO%.yml")},
+			warnings: "filename",
+		},
+		{
+			name:     "tags of inserted and updated keys",
+			filename: "en.json",
+			opts:     []lokalise.ImportOption{lokalise.WithTagInsertedKeys("new"), lokalise.WithTagUpdatedKeys("changed")},
+		},
+		{
+			name:     "tags with tags of inserted and updated keys",
+			filename: "en.json",
+			opts: []lokalise.ImportOption{
+				lokalise.WithTags("release"),
+				lokalise.WithTagInsertedKeys("new"),
+				lokalise.WithTagUpdatedKeys("changed"),
+			},
+			warnings: "tag_inserted_keys,tag_updated_keys",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := lokalise.ValidateImport(tt.filename, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := issueOptions(warnings); got != tt.warnings {
+				t.Errorf("warnings = %v, want issues of %q", warnings, tt.warnings)
+			}
+		})
+	}
+}
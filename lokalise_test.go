@@ -0,0 +1,78 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lokalise")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"valid", "token = \"secret\"\n[export]\nuse_original = true\n", false},
+		{"integer boolean", "token = \"secret\"\n[export]\nuse_original = 1\n", true},
+		{"string boolean", "token = \"secret\"\n[export]\nuse_original = \"1\"\n", true},
+		{"malformed import table", "[import]\nreplace = \"yes\"\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.Replace(tt.name, " ", "_", -1)+".cfg")
+			if err := ioutil.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			conf, err := loadConfig(path)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), path) {
+					t.Errorf("error = %v, want the config file reported", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if conf.Token != "secret" || conf.Export.Original == nil || !*conf.Export.Original {
+				t.Errorf("config = %+v, want the token and use_original set", conf)
+			}
+		})
+	}
+
+	t.Run("missing", func(t *testing.T) {
+		path := filepath.Join(dir, "missing.cfg")
+		if _, err := loadConfig(path); err == nil || !strings.Contains(err.Error(), path) {
+			t.Errorf("error = %v, want the config file reported", err)
+		}
+	})
+
+	t.Run("default", func(t *testing.T) {
+		defer func(file string) { defaultConfigFile = file }(defaultConfigFile)
+
+		// The default file is optional, even if it cannot be read.
+		for _, file := range []string{filepath.Join(dir, "missing.cfg"), dir} {
+			defaultConfigFile = file
+			conf, err := loadConfig("")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if conf.Token != "" {
+				t.Errorf("config = %+v, want it empty", conf)
+			}
+		}
+
+		// But it must be valid.
+		defaultConfigFile = filepath.Join(dir, "malformed_import_table.cfg")
+		if _, err := loadConfig(""); err == nil {
+			t.Error("got no error for a malformed default config")
+		}
+	})
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+	"github.com/urfave/cli"
+)
+
+// imageExtensions are the file extensions screenshots upload picks up.
+var imageExtensions = []string{".png", ".jpg", ".jpeg", ".gif", ".webp"}
+
+// screenshotMapping describes a single image in a --mapping file.
+type screenshotMapping struct {
+	Keys        []string `json:"keys"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+}
+
+var screenshotsCommand = cli.Command{
+	Name:  "screenshots",
+	Usage: "Upload screenshots giving translators context.",
+	Subcommands: []cli.Command{
+		{
+			Name:      "upload",
+			Usage:     "Upload the images of a directory and link them to keys.",
+			ArgsUsage: "<project id>",
+			Description: `Images are linked to the keys named in the --mapping file, which maps image
+   filenames to an object with "keys", "title", "description" and "tags":
+
+     {"login.png": {"keys": ["login.title", "login.button"], "title": "Login"}}
+
+   Without --mapping the key names are taken from the filename, separated by
+   "+", e.g. "login.title+login.button.png". Titles default to the filename.`,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "dir",
+					Usage: "Directory with the images to upload. (" + strings.Join(imageExtensions, ", ") + ", required)",
+				},
+				cli.StringFlag{
+					Name:  "mapping",
+					Usage: "JSON `file` mapping image filenames to keys, title, description and tags.",
+				},
+				cli.StringFlag{
+					Name:  "description",
+					Usage: "Description of all screenshots, unless set in the mapping.",
+				},
+				cli.StringFlag{
+					Name:  "tags",
+					Usage: "Tags of all screenshots, added to those in the mapping. (comma separated)",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				dir := c.String("dir")
+				if dir == "" {
+					return cli.NewExitError("ERROR: --dir is required. Run `lokalise help screenshots upload` for all options.", 5)
+				}
+				files, err := imageFiles(dir)
+				if err != nil {
+					return cli.NewExitError("ERROR: "+err.Error(), 5)
+				}
+				var mapping map[string]screenshotMapping
+				if file := c.String("mapping"); file != "" {
+					content, err := ioutil.ReadFile(file)
+					if err != nil {
+						return cli.NewExitError("ERROR: "+err.Error(), 5)
+					}
+					if err := json.Unmarshal(content, &mapping); err != nil {
+						return cli.NewExitError("ERROR: invalid mapping file "+file+": "+err.Error(), 5)
+					}
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				keyIDs, err := keyIDsByName(ctx, client, projectID)
+				if err != nil {
+					return apiError(err)
+				}
+				cWhite := color.New(color.FgHiWhite)
+				cGreen := color.New(color.FgGreen)
+				cRed := color.New(color.FgRed)
+				for _, file := range files {
+					name := filepath.Base(file)
+					m, ok := mapping[name]
+					if mapping != nil && !ok {
+						cRed.Println("WARNING: " + name + " is not in the mapping file, skipping.")
+						continue
+					}
+					if !ok {
+						m.Keys = strings.Split(strings.TrimSuffix(name, filepath.Ext(name)), "+")
+					}
+					if m.Title == "" {
+						m.Title = strings.TrimSuffix(name, filepath.Ext(name))
+					}
+					if m.Description == "" {
+						m.Description = c.String("description")
+					}
+					m.Tags = append(m.Tags, commaSlice(c.String("tags"))...)
+
+					var ids []int64
+					for _, key := range m.Keys {
+						id, ok := keyIDs[key]
+						if !ok {
+							cRed.Println("WARNING: key " + key + " of " + name + " not found, not linking it.")
+							continue
+						}
+						ids = append(ids, id)
+					}
+					opts := []lokalise.ScreenshotOption{lokalise.WithScreenshotTitle(m.Title)}
+					if m.Description != "" {
+						opts = append(opts, lokalise.WithScreenshotDescription(m.Description))
+					}
+					if len(m.Tags) != 0 {
+						opts = append(opts, lokalise.WithScreenshotTags(m.Tags...))
+					}
+					if len(ids) != 0 {
+						opts = append(opts, lokalise.WithScreenshotKeys(ids...))
+					}
+
+					cWhite.Printf("Uploading %s... ", file)
+					shot, err := client.UploadScreenshotFile(ctx, projectID, file, opts...)
+					if err != nil {
+						fmt.Println()
+						return apiError(err)
+					}
+					cGreen.Print("Uploaded ")
+					fmt.Printf("%d, linked to %d keys.\n", shot.ID, len(shot.KeyIDs))
+				}
+				return nil
+			},
+		},
+	},
+}
+
+// imageFiles returns the images in dir in lexical order.
+func imageFiles(dir string) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		for _, image := range imageExtensions {
+			if !entry.IsDir() && ext == image {
+				files = append(files, filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no images in %s", dir)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// keyIDsByName maps the key names of project with ID projectID to key IDs.
+func keyIDsByName(ctx context.Context, client *lokalise.Client, projectID string) (map[string]int64, error) {
+	keys, err := allKeys(ctx, client, projectID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]int64, len(keys))
+	for _, k := range keys {
+		ids[k.Name] = k.ID
+	}
+	return ids, nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+	"github.com/urfave/cli"
+)
+
+var snapshotsCommand = cli.Command{
+	Name:  "snapshots",
+	Usage: "Create, list and restore project snapshots.",
+	Subcommands: []cli.Command{
+		{
+			Name:      "list",
+			Usage:     "List project snapshots.",
+			ArgsUsage: "<project id>",
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				snapshots, err := client.ListSnapshots(ctx, projectID)
+				if err != nil {
+					return apiError(err)
+				}
+				cWhite := color.New(color.FgHiWhite)
+				cCyan := color.New(color.FgCyan)
+				for _, snap := range snapshots {
+					cWhite.Printf("%-10d ", snap.ID)
+					cCyan.Print(snap.Created.Format("2006-01-02 15:04:05"))
+					fmt.Println(" " + snap.Title)
+				}
+				return nil
+			},
+		},
+		{
+			Name:      "create",
+			Usage:     "Take a snapshot of the project.",
+			ArgsUsage: "<project id>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "title",
+					Usage: "Snapshot title. Defaults to the current time.",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				title := c.String("title")
+				if title == "" {
+					title = "Snapshot " + time.Now().Format("2006-01-02 15:04:05")
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				snap, err := client.CreateSnapshot(ctx, projectID, title)
+				if err != nil {
+					return apiError(err)
+				}
+				printSnapshot(projectID, snap)
+				return nil
+			},
+		},
+		{
+			Name:      "restore",
+			Usage:     "Restore a snapshot to a new copy of the project.",
+			ArgsUsage: "<project id>",
+			Flags: []cli.Flag{
+				cli.Int64Flag{
+					Name:  "snapshot_id",
+					Usage: "ID of the snapshot to restore. (required)",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				snapshotID := c.Int64("snapshot_id")
+				if snapshotID == 0 {
+					return cli.NewExitError("ERROR: --snapshot_id is required. Run `lokalise help snapshots restore` for all options.", 5)
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				project, err := client.RestoreSnapshot(ctx, projectID, snapshotID)
+				if err != nil {
+					return apiError(err)
+				}
+				color.New(color.FgGreen).Print("Restored to project ")
+				fmt.Println(project.ID, project.Name)
+				return nil
+			},
+		},
+	},
+}
+
+// snapshotBeforeImport takes a snapshot of the project before an import in
+// replace or cleanup mode.
+func snapshotBeforeImport(ctx context.Context, apiToken, projectID string) (*lokalise.Snapshot, error) {
+	client, err := lokalise.NewClient(lokalise.WithAPIToken(apiToken))
+	if err != nil {
+		return nil, cli.NewExitError("ERROR: "+err.Error(), 5)
+	}
+	snap, err := client.CreateSnapshot(ctx, projectID, "Before import "+time.Now().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, apiError(err)
+	}
+	printSnapshot(projectID, snap)
+	return &snap, nil
+}
+
+func printSnapshot(projectID string, snap lokalise.Snapshot) {
+	color.New(color.FgGreen).Print("Created snapshot ")
+	fmt.Printf("%d %q\n", snap.ID, snap.Title)
+	printRestoreHint(projectID, snap)
+}
+
+func printRestoreHint(projectID string, snap lokalise.Snapshot) {
+	fmt.Printf("Restore it with: lokalise snapshots restore %s --snapshot_id %d\n", projectID, snap.ID)
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+	"github.com/urfave/cli"
+)
+
+var tasksCommand = cli.Command{
+	Name:  "tasks",
+	Usage: "Create, list and close translation tasks.",
+	Subcommands: []cli.Command{
+		{
+			Name:      "list",
+			Usage:     "List project tasks.",
+			ArgsUsage: "<project id>",
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				tasks, err := client.ListTasks(ctx, projectID)
+				if err != nil {
+					return apiError(err)
+				}
+				for _, task := range tasks {
+					printTask(task)
+				}
+				return nil
+			},
+		},
+		{
+			Name:      "create",
+			Usage:     "Create a task to translate keys.",
+			ArgsUsage: "<project id>",
+			Description: `Select the keys with --key_ids or --tags. To create a task for the keys
+   inserted by an import, tag them on import and pass the same tag:
+
+     lokalise import <project id> --file en.json --lang_iso en --tag_inserted_keys release-42
+     lokalise tasks create <project id> --title "Release 42" --tags release-42`,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "title",
+					Usage: "Task title. (required)",
+				},
+				cli.StringFlag{
+					Name:  "description",
+					Usage: "Task description.",
+				},
+				cli.StringFlag{
+					Name:  "due_date",
+					Usage: "Date the task is due, as `YYYY-MM-DD`.",
+				},
+				cli.StringFlag{
+					Name:  "key_ids",
+					Usage: "IDs of the keys to translate. (comma separated)",
+				},
+				cli.StringFlag{
+					Name:  "tags",
+					Usage: "Translate the keys with any of these tags, e.g. the --tag_inserted_keys of an import. (comma separated)",
+				},
+				cli.StringFlag{
+					Name:  "langs",
+					Usage: "Languages to translate into. Defaults to all but the base language. (comma separated)",
+				},
+				cli.StringFlag{
+					Name:  "assignees",
+					Usage: "Emails or user IDs of the contributors to assign to all languages. (comma separated)",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				title := c.String("title")
+				if title == "" {
+					return cli.NewExitError("ERROR: --title is required. Run `lokalise help tasks create` for all options.", 5)
+				}
+				keyIDs, err := keyIDSlice(c.String("key_ids"))
+				if err != nil {
+					return err
+				}
+				tags := commaSlice(c.String("tags"))
+				if len(keyIDs) == 0 && len(tags) == 0 {
+					return cli.NewExitError("ERROR: --key_ids or --tags is required. Run `lokalise help tasks create` for all options.", 5)
+				}
+				var opts []lokalise.TaskOption
+				if description := c.String("description"); description != "" {
+					opts = append(opts, lokalise.WithTaskDescription(description))
+				}
+				if v := c.String("due_date"); v != "" {
+					due, err := time.Parse("2006-01-02", v)
+					if err != nil {
+						return cli.NewExitError("ERROR: invalid --due_date "+v+", expected YYYY-MM-DD.", 5)
+					}
+					opts = append(opts, lokalise.WithDueDate(due))
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				if len(tags) != 0 {
+					keys, err := allKeys(ctx, client, projectID, lokalise.WithKeyTags(tags...))
+					if err != nil {
+						return apiError(err)
+					}
+					for _, k := range keys {
+						keyIDs = append(keyIDs, k.ID)
+					}
+					if len(keyIDs) == 0 {
+						return cli.NewExitError("ERROR: no keys tagged "+strings.Join(tags, ", ")+".", 5)
+					}
+				}
+				// Keys given by ID may carry the tags as well.
+				keyIDs = uniqueIDs(keyIDs)
+				isos := commaSlice(c.String("langs"))
+				if len(isos) == 0 {
+					project, err := client.GetProject(ctx, projectID)
+					if err != nil {
+						return apiError(err)
+					}
+					for _, l := range project.Languages {
+						if l.ISO != project.BaseLanguage {
+							isos = append(isos, l.ISO)
+						}
+					}
+				}
+				users, err := assigneeIDs(ctx, client, projectID, commaSlice(c.String("assignees")))
+				if err != nil {
+					return err
+				}
+				var languages []lokalise.TaskLanguage
+				for _, iso := range isos {
+					languages = append(languages, lokalise.TaskLanguage{ISO: strings.TrimSpace(iso), Users: users})
+				}
+
+				task, err := client.CreateTask(ctx, projectID, title, keyIDs, languages, opts...)
+				if err != nil {
+					return apiError(err)
+				}
+				color.New(color.FgGreen).Print("Created task ")
+				printTask(task)
+				return nil
+			},
+		},
+		{
+			Name:      "close",
+			Usage:     "Close a task.",
+			ArgsUsage: "<project id>",
+			Flags: []cli.Flag{
+				cli.Int64Flag{
+					Name:  "task_id",
+					Usage: "ID of the task to close. (required)",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				taskID := c.Int64("task_id")
+				if taskID == 0 {
+					return cli.NewExitError("ERROR: --task_id is required. Run `lokalise help tasks close` for all options.", 5)
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				task, err := client.CloseTask(ctx, projectID, taskID)
+				if err != nil {
+					return apiError(err)
+				}
+				color.New(color.FgGreen).Print("Closed task ")
+				printTask(task)
+				return nil
+			},
+		},
+	},
+}
+
+// assigneeIDs resolves assignees, given as emails or user IDs, to the user
+// IDs of contributors of project with ID projectID.
+func assigneeIDs(ctx context.Context, client *lokalise.Client, projectID string, assignees []string) ([]int64, error) {
+	if len(assignees) == 0 {
+		return nil, nil
+	}
+	contributors, err := client.ListContributors(ctx, projectID)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	var ids []int64
+	for _, assignee := range assignees {
+		assignee = strings.TrimSpace(assignee)
+		found := false
+		for _, contributor := range contributors {
+			if strings.EqualFold(contributor.Email, assignee) || strconv.FormatInt(contributor.ID, 10) == assignee {
+				ids = append(ids, contributor.ID)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, cli.NewExitError("ERROR: "+assignee+" is not a contributor of the project.", 5)
+		}
+	}
+	return ids, nil
+}
+
+// uniqueIDs returns ids without duplicates, in the order of their first
+// occurrence.
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	unique := ids[:0]
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func printTask(task lokalise.Task) {
+	color.New(color.FgHiWhite).Printf("%-10d ", task.ID)
+	fmt.Print(task.Title)
+	color.New(color.FgCyan).Printf(" [%s]", task.Status)
+	var languages []string
+	for _, l := range task.Languages {
+		languages = append(languages, l.ISO)
+	}
+	fmt.Printf(" %d keys into %s", len(task.KeyIDs), strings.Join(languages, ", "))
+	if !task.DueDate.IsZero() {
+		fmt.Print(", due " + task.DueDate.Format("2006-01-02"))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/lokalise/lokalise-cli-go/lokalise"
+	"github.com/urfave/cli"
+)
+
+var translationCommand = cli.Command{
+	Name:  "translation",
+	Usage: "Read and edit single translations.",
+	Subcommands: []cli.Command{
+		{
+			Name:      "get",
+			Usage:     "Print the translations of a key.",
+			ArgsUsage: "<project id>",
+			Flags: []cli.Flag{
+				cli.Int64Flag{
+					Name:  "key_id",
+					Usage: "ID of the key. (required)",
+				},
+				cli.StringFlag{
+					Name:  "lang_iso",
+					Usage: "Language of the translation. Don't specify for all languages.",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				keyID := c.Int64("key_id")
+				if keyID == 0 {
+					return cli.NewExitError("ERROR: --key_id is required. Run `lokalise help translation get` for all options.", 5)
+				}
+				opts := []lokalise.ListOption{lokalise.WithKeyIDs(keyID)}
+				if iso := c.String("lang_iso"); iso != "" {
+					opts = append(opts, lokalise.WithLanguageISOs(iso))
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				for p := 1; ; p++ {
+					page, err := client.ListTranslations(ctx, projectID, append(opts, lokalise.WithPage(p, 0))...)
+					if err != nil {
+						return apiError(err)
+					}
+					for _, t := range page.Translations {
+						printTranslation(t)
+					}
+					if !page.Next() {
+						return nil
+					}
+				}
+			},
+		},
+		{
+			Name:      "set",
+			Usage:     "Change a translation and its status.",
+			ArgsUsage: "<project id>",
+			Flags: []cli.Flag{
+				cli.Int64Flag{
+					Name:  "key_id",
+					Usage: "ID of the key. (required)",
+				},
+				cli.StringFlag{
+					Name:  "lang_iso",
+					Usage: "Language of the translation. (required)",
+				},
+				cli.StringFlag{
+					Name:  "text",
+					Usage: "Translation of a singular key.",
+				},
+				cli.StringSliceFlag{
+					Name:  "plural",
+					Usage: "Translation of a plural form of a plural key as `form=text`. Repeat for each form.",
+				},
+				cli.StringFlag{
+					Name:  "reviewed",
+					Usage: "Mark as reviewed. (`0/1`)",
+				},
+				cli.StringFlag{
+					Name:  "unverified",
+					Usage: "Mark as unverified. (`0/1`)",
+				},
+				cli.StringFlag{
+					Name:  "fuzzy",
+					Usage: "Mark as fuzzy. (`0/1`)",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				client, projectID, err := projectClient(c)
+				if err != nil {
+					return err
+				}
+				keyID, iso := c.Int64("key_id"), c.String("lang_iso")
+				if keyID == 0 || iso == "" {
+					return cli.NewExitError("ERROR: --key_id and --lang_iso are required. Run `lokalise help translation set` for all options.", 5)
+				}
+
+				if c.IsSet("text") && len(c.StringSlice("plural")) != 0 {
+					return cli.NewExitError("ERROR: --text and --plural are mutually exclusive. Use --text for singular keys and --plural for plural keys.", 5)
+				}
+
+				var opts []lokalise.TranslationOption
+				if c.IsSet("text") {
+					opts = append(opts, lokalise.WithText(c.String("text")))
+				}
+				if plurals := c.StringSlice("plural"); len(plurals) != 0 {
+					forms := map[string]string{}
+					for _, p := range plurals {
+						i := strings.Index(p, "=")
+						if i < 0 {
+							return cli.NewExitError("ERROR: --plural must be form=text, got "+p+".", 5)
+						}
+						forms[p[:i]] = p[i+1:]
+					}
+					opts = append(opts, lokalise.WithPlurals(forms))
+				}
+				for flag, option := range map[string]func(bool) lokalise.TranslationOption{
+					"reviewed":   lokalise.WithReviewed,
+					"unverified": lokalise.WithUnverified,
+					"fuzzy":      lokalise.WithFuzzy,
+				} {
+					if v := c.String(flag); v != "" {
+						b, _ := strconv.ParseBool(v)
+						opts = append(opts, option(b))
+					}
+				}
+				if len(opts) == 0 {
+					return cli.NewExitError("ERROR: nothing to change. Run `lokalise help translation set` for all options.", 5)
+				}
+				ctx, cancel := interruptContext()
+				defer cancel()
+
+				t, err := client.UpdateTranslation(ctx, projectID, keyID, iso, opts...)
+				if err != nil {
+					return apiError(err)
+				}
+				printTranslation(t)
+				return nil
+			},
+		},
+	},
+}
+
+func printTranslation(t lokalise.Translation) {
+	cWhite := color.New(color.FgHiWhite)
+	cCyan := color.New(color.FgCyan)
+	cWhite.Printf("%-10s", t.Language)
+	var statuses []string
+	for status, set := range map[string]bool{"reviewed": t.Reviewed, "unverified": t.Unverified, "fuzzy": t.Fuzzy} {
+		if set {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Strings(statuses)
+	if len(statuses) != 0 {
+		cCyan.Printf(" (%s)", strings.Join(statuses, ", "))
+	}
+	if t.Plurals == nil {
+		fmt.Println(" " + t.Text)
+		return
+	}
+	fmt.Println()
+	forms := make([]string, 0, len(t.Plurals))
+	for form := range t.Plurals {
+		forms = append(forms, form)
+	}
+	sort.Strings(forms)
+	for _, form := range forms {
+		cCyan.Printf("  %-6s", form)
+		fmt.Println(" " + t.Plurals[form])
+	}
+}